@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,6 +31,8 @@ import (
 const (
 	RoleControlPlane = "control-plane"
 	RoleWorker       = "worker"
+	// RoleMaster is the deprecated name of RoleControlPlane
+	RoleMaster = "master"
 )
 
 const (
@@ -62,3 +65,55 @@ func GetBySelector(env *deployer.Environment, selector labels.Selector) ([]corev
 	}
 	return nodes.Items, nil
 }
+
+// GetNonControlPlane returns all nodes without control plane roles
+func GetNonControlPlane(env *deployer.Environment) ([]corev1.Node, error) {
+	all, err := GetBySelector(env, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return FilterNonControlPlane(all), nil
+}
+
+// FilterNonControlPlane returns the nodes without control plane roles
+func FilterNonControlPlane(nodes []corev1.Node) []corev1.Node {
+	var ret []corev1.Node
+	for _, node := range nodes {
+		if IsControlPlane(node) {
+			continue
+		}
+		ret = append(ret, node)
+	}
+	return ret
+}
+
+// IsControlPlane returns true if the node has any control plane role
+func IsControlPlane(node corev1.Node) bool {
+	for _, role := range []string{RoleControlPlane, RoleMaster} {
+		if _, ok := node.Labels[fmt.Sprintf("%s/%s", LabelRole, role)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTargets returns the nodes matching the given selector. If the selector is empty, returns the
+// worker nodes; if no node has the worker role, returns all the nodes without control plane roles.
+func GetTargets(env *deployer.Environment, sel *metav1.LabelSelector) ([]corev1.Node, error) {
+	if sel != nil && (len(sel.MatchLabels) > 0 || len(sel.MatchExpressions) > 0) {
+		selector, err := metav1.LabelSelectorAsSelector(sel)
+		if err != nil {
+			return nil, err
+		}
+		return GetBySelector(env, selector)
+	}
+	workers, err := GetWorkers(env)
+	if err != nil {
+		return nil, err
+	}
+	if len(workers) > 0 {
+		return workers, nil
+	}
+	env.Log.V(3).Info("no nodes with the worker role, falling back to nodes without control plane roles")
+	return GetNonControlPlane(env)
+}
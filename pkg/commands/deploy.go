@@ -21,13 +21,14 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/k8stopologyawareschedwg/deployer/pkg/deploy"
+	deploypkg "github.com/k8stopologyawareschedwg/deployer/pkg/deploy"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/api"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform/detect"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
@@ -36,11 +37,12 @@ func NewDeployCommand(env *deployer.Environment, commonOpts *options.Options) *c
 		Use:   "deploy",
 		Short: "deploy the components and configurations needed for topology-aware-scheduling",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return deploy.OnCluster(env, commonOpts)
+			return deploypkg.OnCluster(env, commonOpts)
 		},
 		Args: cobra.NoArgs,
 	}
 	deploy.PersistentFlags().BoolVarP(&commonOpts.WaitCompletion, "wait", "W", false, "wait for deployment to be all completed.")
+	deploy.PersistentFlags().StringVar(&commonOpts.OnConflict, "on-conflict", string(deploypkg.DefaultConflictPolicy), "what to do if an existing topology-aware-scheduling installation is found: fail, adopt, skip-component.")
 	deploy.AddCommand(NewDeployAPICommand(env, commonOpts))
 	deploy.AddCommand(NewDeploySchedulerPluginCommand(env, commonOpts))
 	deploy.AddCommand(NewDeployTopologyUpdaterCommand(env, commonOpts))
@@ -68,7 +70,14 @@ func NewDeployAPICommand(env *deployer.Environment, commonOpts *options.Options)
 			}
 
 			env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
-			if err := api.Deploy(env, options.API{Platform: commonOpts.ClusterPlatform}); err != nil {
+			plan, err := deploypkg.PlanConflicts(env, commonOpts, deploypkg.ComponentAPI)
+			if err != nil {
+				return err
+			}
+			if plan.ShouldSkip(deploypkg.ComponentAPI) {
+				return nil
+			}
+			if err := api.Deploy(env, options.API{Platform: commonOpts.ClusterPlatform, Adopt: plan.ShouldAdopt(deploypkg.ComponentAPI), Patches: commonOpts.Patches[manifests.ComponentAPI]}); err != nil {
 				return err
 			}
 			return nil
@@ -101,8 +110,31 @@ func NewDeploySchedulerPluginCommand(env *deployer.Environment, commonOpts *opti
 			}
 
 			env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
+			flavorDetect, flavorSource, _ := detect.FindFlavor(env.Ctx, commonOpts.ClusterPlatform, commonOpts.UserFlavor)
+			commonOpts.ClusterFlavor = flavorDetect.Discovered
+			env.Log.V(3).Info("detection", "flavor", commonOpts.ClusterFlavor, "source", flavorSource)
+			plan, err := deploypkg.PlanConflicts(env, commonOpts, deploypkg.ComponentScheduler)
+			if err != nil {
+				return err
+			}
+			if err := deploypkg.SelectImages(env.Log, commonOpts); err != nil {
+				return err
+			}
+			if err := deploypkg.DiscoverAPIServer(env, commonOpts); err != nil {
+				return err
+			}
+			if err := deploypkg.CheckPodSecurity(env, commonOpts, plan, deploypkg.ComponentScheduler); err != nil {
+				return err
+			}
+			if err := deploypkg.LoadImagePullSecret(env, commonOpts); err != nil {
+				return err
+			}
+			if plan.ShouldSkip(deploypkg.ComponentScheduler) {
+				return nil
+			}
 			return sched.Deploy(env, options.Scheduler{
 				Platform:               commonOpts.ClusterPlatform,
+				Flavor:                 commonOpts.ClusterFlavor,
 				WaitCompletion:         commonOpts.WaitCompletion,
 				Replicas:               int32(commonOpts.Replicas),
 				PullIfNotPresent:       commonOpts.PullIfNotPresent,
@@ -114,6 +146,21 @@ func NewDeploySchedulerPluginCommand(env *deployer.Environment, commonOpts *opti
 				CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
 				LeaderElection:         commonOpts.Replicas > 1,
 				LeaderElectionResource: commonOpts.SchedLeaderElectResource,
+				LeaseDuration:          commonOpts.SchedLeaseDuration,
+				RenewDeadline:          commonOpts.SchedRenewDeadline,
+				RetryPeriod:            commonOpts.SchedRetryPeriod,
+				HighAvailability:       commonOpts.SchedHighAvailability,
+				PriorityClassName:      commonOpts.SchedPriorityClassName,
+				SchedulerResources:     commonOpts.SchedResources,
+				ControllerResources:    commonOpts.SchedControllerResources,
+				Patches:                commonOpts.Patches[manifests.ComponentSchedulerPlugin],
+				Images:                 commonOpts.Images,
+				Metrics:                commonOpts.Metrics,
+				NetworkPolicies:        commonOpts.NetworkPolicies,
+				ImageMirrors:           commonOpts.ImageMirrors,
+				ImagePullSecret:        commonOpts.ImagePullSecret,
+				ImagePullSecretSource:  commonOpts.ImagePullSecretSource,
+				Adopt:                  plan.ShouldAdopt(deploypkg.ComponentScheduler),
 			})
 		},
 		Args: cobra.NoArgs,
@@ -144,14 +191,43 @@ func NewDeployTopologyUpdaterCommand(env *deployer.Environment, commonOpts *opti
 			}
 
 			env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
+			flavorDetect, flavorSource, _ := detect.FindFlavor(env.Ctx, commonOpts.ClusterPlatform, commonOpts.UserFlavor)
+			commonOpts.ClusterFlavor = flavorDetect.Discovered
+			env.Log.V(3).Info("detection", "flavor", commonOpts.ClusterFlavor, "source", flavorSource)
+			plan, err := deploypkg.PlanConflicts(env, commonOpts, deploypkg.ComponentUpdater)
+			if err != nil {
+				return err
+			}
+			if err := deploypkg.SelectImages(env.Log, commonOpts); err != nil {
+				return err
+			}
+			if err := deploypkg.DiscoverAPIServer(env, commonOpts); err != nil {
+				return err
+			}
+			if err := deploypkg.CheckPodSecurity(env, commonOpts, plan, deploypkg.ComponentUpdater); err != nil {
+				return err
+			}
+			if err := deploypkg.LoadImagePullSecret(env, commonOpts); err != nil {
+				return err
+			}
+			if plan.ShouldSkip(deploypkg.ComponentUpdater) {
+				return nil
+			}
 			return updaters.Deploy(env, commonOpts.UpdaterType, options.Updater{
-				Platform:            commonOpts.ClusterPlatform,
-				PlatformVersion:     commonOpts.ClusterVersion,
-				WaitCompletion:      commonOpts.WaitCompletion,
-				RTEConfigData:       commonOpts.RTEConfigData,
-				DaemonSet:           options.ForDaemonSet(commonOpts),
-				EnableCRIHooks:      commonOpts.UpdaterCRIHooksEnable,
-				CustomSELinuxPolicy: commonOpts.UpdaterCustomSELinuxPolicy,
+				Platform:              commonOpts.ClusterPlatform,
+				PlatformVersion:       commonOpts.ClusterVersion,
+				WaitCompletion:        commonOpts.WaitCompletion,
+				RTEConfigData:         commonOpts.RTEConfigData,
+				DaemonSet:             options.ForDaemonSet(commonOpts),
+				Patches:               commonOpts.Patches[updaters.ComponentFromType(commonOpts.UpdaterType)],
+				ImageMirrors:          commonOpts.ImageMirrors,
+				ImagePullSecretSource: commonOpts.ImagePullSecretSource,
+				Metrics:               commonOpts.Metrics,
+				NetworkPolicies:       commonOpts.NetworkPolicies,
+				EnableCRIHooks:        commonOpts.UpdaterCRIHooksEnable,
+				CustomSELinuxPolicy:   commonOpts.UpdaterCustomSELinuxPolicy,
+				NodePoolNamespace:     commonOpts.NodePoolNamespace,
+				Adopt:                 plan.ShouldAdopt(deploypkg.ComponentUpdater),
 			})
 		},
 		Args: cobra.NoArgs,
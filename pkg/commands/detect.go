@@ -28,6 +28,7 @@ import (
 
 type detectOptions struct {
 	controlPlane bool
+	capabilities bool
 	jsonOutput   bool
 }
 
@@ -50,14 +51,25 @@ func NewDetectCommand(env *deployer.Environment, commonOpts *options.Options) *c
 
 			}
 
+			if opts.capabilities {
+				info, err := detect.Capabilities(env.Ctx)
+				if err != nil {
+					return err
+				}
+				serialize(opts, info)
+				return nil
+			}
+
 			platKind, kindReason, _ := detect.FindPlatform(env.Ctx, commonOpts.UserPlatform)
 			platVer, verReason, _ := detect.FindVersion(env.Ctx, platKind.Discovered, commonOpts.UserPlatformVersion)
+			platFlavor, flavorReason, _ := detect.FindFlavor(env.Ctx, platKind.Discovered, commonOpts.UserFlavor)
 
-			env.Log.V(3).Info("detection", "platform", platKind, "reason", kindReason, "version", platVer, "source", verReason)
+			env.Log.V(3).Info("detection", "platform", platKind, "reason", kindReason, "version", platVer, "source", verReason, "flavor", platFlavor, "flavorSource", flavorReason)
 
 			cluster := detect.ClusterInfo{
 				Platform: platKind,
 				Version:  platVer,
+				Flavor:   platFlavor,
 			}
 			serialize(opts, cluster)
 			return nil
@@ -66,6 +78,7 @@ func NewDetectCommand(env *deployer.Environment, commonOpts *options.Options) *c
 	}
 	detect.Flags().BoolVarP(&opts.jsonOutput, "json", "J", false, "output JSON, not text.")
 	detect.Flags().BoolVar(&opts.controlPlane, "control-plane", false, "detect control plane info, not cluster info")
+	detect.Flags().BoolVar(&opts.capabilities, "capabilities", false, "detect the cluster capabilities relevant for topology-aware-scheduling, always as JSON")
 	return detect
 }
 
@@ -17,19 +17,32 @@
 package commands
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
+// mirrorSetMinOpenShiftVersion is the first OpenShift version supporting ImageDigestMirrorSet and ImageTagMirrorSet
+const mirrorSetMinOpenShiftVersion = "4.13"
+
 type ImagesOptions struct {
 	jsonOutput bool
 	rawOutput  bool
 	useSHA     bool
+	mirrorFile string
+	forVersion string
 }
 
 func NewImagesCommand(env *deployer.Environment, commonOpts *options.Options) *cobra.Command {
@@ -39,11 +52,21 @@ func NewImagesCommand(env *deployer.Environment, commonOpts *options.Options) *c
 		Short: "dump the container images used to deploy",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			imgs := images.GetWithFunc(opts.useSHA, os.LookupEnv)
+			if opts.forVersion != "" {
+				var err error
+				imgs, err = imagesForVersion(env, commonOpts.UserPlatform, opts.forVersion, opts.useSHA)
+				if err != nil {
+					return err
+				}
+			}
 			fk := images.FormatText
 			if opts.jsonOutput {
 				fk = images.FormatJSON
 			}
 			imo := images.NewOutput(imgs, commonOpts.UpdaterType)
+			if opts.mirrorFile != "" {
+				return writeMirrorFile(opts.mirrorFile, commonOpts, imo)
+			}
 			var of images.Formatter = imo
 			if opts.rawOutput {
 				of = imo.ToList()
@@ -56,5 +79,67 @@ func NewImagesCommand(env *deployer.Environment, commonOpts *options.Options) *c
 	images.Flags().BoolVarP(&opts.jsonOutput, "json", "J", false, "output JSON, not text (default).")
 	images.Flags().BoolVarP(&opts.rawOutput, "raw", "r", false, "output raw list. Default is key=value object.")
 	images.Flags().BoolVarP(&opts.useSHA, "sha", "S", false, "emit SHA256 pullspects, not tag pullspecs.")
+	images.Flags().StringVar(&opts.forVersion, "for-version", "", "emit the images of the newest bundled image set supporting this cluster version (an OpenShift version with -P openshift).")
+	images.Flags().StringVar(&opts.mirrorFile, "mirror-file", "", "write the --image-registry-mirror configuration to this file (\"-\" for stdout): ImageDigestMirrorSet and ImageTagMirrorSet (ImageContentSourcePolicy before 4.13) on OpenShift, a \"source=mirror\" image list elsewhere.")
 	return images
 }
+
+func imagesForVersion(env *deployer.Environment, plat platform.Platform, version string, useSHA bool) (images.Images, error) {
+	ver, err := platform.ParseVersion(version)
+	if err != nil {
+		return images.Images{}, err
+	}
+	kubeVer, err := platform.KubernetesVersion(plat, ver)
+	if err != nil {
+		return images.Images{}, err
+	}
+	is, ok := images.SelectImageSet(kubeVer.String())
+	if !ok {
+		return images.Images{}, fmt.Errorf("no bundled image set supports kubernetes version %q (supported: %s)", kubeVer, strings.Join(images.SupportedKubernetesVersions(), ", "))
+	}
+	env.Log.Info("image set selected", "name", is.Name, "kubernetesVersion", kubeVer)
+	imgs, err := is.Images(useSHA)
+	if err != nil {
+		return images.Images{}, err
+	}
+	return imgs.WithOverrides(os.LookupEnv), nil
+}
+
+func writeMirrorFile(path string, commonOpts *options.Options, imo images.Output) error {
+	if len(commonOpts.ImageMirrors) == 0 {
+		return fmt.Errorf("--mirror-file requires at least one --image-registry-mirror")
+	}
+	imageList := imo.ToList()
+
+	var buf bytes.Buffer
+	if commonOpts.UserPlatform == platform.OpenShift {
+		err := encodeMirrorSets(&buf, commonOpts.UserPlatformVersion, commonOpts.ImageMirrors.Used(imageList))
+		if err != nil {
+			return err
+		}
+	} else {
+		commonOpts.ImageMirrors.Mapping(imageList).EncodeText(&buf)
+	}
+
+	if path == "-" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+func encodeMirrorSets(w io.Writer, ver platform.Version, mirrors images.Mirrors) error {
+	if len(mirrors) == 0 {
+		return fmt.Errorf("no mirror matches the deployed images")
+	}
+	if ver != platform.MissingVersion {
+		ok, err := ver.AtLeastString(mirrorSetMinOpenShiftVersion)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return manifests.RenderObjects([]client.Object{mirrors.ImageContentSourcePolicy()}, w)
+		}
+	}
+	return manifests.RenderObjects([]client.Object{mirrors.ImageDigestMirrorSet(), mirrors.ImageTagMirrorSet()}, w)
+}
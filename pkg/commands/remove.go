@@ -27,6 +27,7 @@ import (
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform/detect"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
@@ -66,6 +67,17 @@ func NewRemoveCommand(env *deployer.Environment, commonOpts *options.Options) *c
 				CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
 				LeaderElection:         commonOpts.Replicas > 1,
 				LeaderElectionResource: commonOpts.SchedLeaderElectResource,
+				LeaseDuration:          commonOpts.SchedLeaseDuration,
+				RenewDeadline:          commonOpts.SchedRenewDeadline,
+				RetryPeriod:            commonOpts.SchedRetryPeriod,
+				HighAvailability:       commonOpts.SchedHighAvailability,
+				PriorityClassName:      commonOpts.SchedPriorityClassName,
+				SchedulerResources:     commonOpts.SchedResources,
+				ControllerResources:    commonOpts.SchedControllerResources,
+				Patches:                commonOpts.Patches[manifests.ComponentSchedulerPlugin],
+				ImageMirrors:           commonOpts.ImageMirrors,
+				ImagePullSecret:        commonOpts.ImagePullSecret,
+				Metrics:                commonOpts.Metrics,
 			})
 			if err != nil {
 				// intentionally keep going to remove as much as possible
@@ -77,6 +89,8 @@ func NewRemoveCommand(env *deployer.Environment, commonOpts *options.Options) *c
 				WaitCompletion:  commonOpts.WaitCompletion,
 				RTEConfigData:   commonOpts.RTEConfigData,
 				DaemonSet:       options.ForDaemonSet(commonOpts),
+				Patches:         commonOpts.Patches[updaters.ComponentFromType(commonOpts.UpdaterType)],
+				ImageMirrors:    commonOpts.ImageMirrors,
 				EnableCRIHooks:  commonOpts.UpdaterCRIHooksEnable,
 			})
 			if err != nil {
@@ -85,6 +99,7 @@ func NewRemoveCommand(env *deployer.Environment, commonOpts *options.Options) *c
 			}
 			err = api.Remove(env, options.API{
 				Platform: commonOpts.ClusterPlatform,
+				Patches:  commonOpts.Patches[manifests.ComponentAPI],
 			})
 			if err != nil {
 				// intentionally keep going to remove as much as possible
@@ -124,7 +139,7 @@ func NewRemoveAPICommand(env *deployer.Environment, commonOpts *options.Options)
 			}
 
 			env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
-			if err := api.Remove(env, options.API{Platform: commonOpts.ClusterPlatform}); err != nil {
+			if err := api.Remove(env, options.API{Platform: commonOpts.ClusterPlatform, Patches: commonOpts.Patches[manifests.ComponentAPI]}); err != nil {
 				return err
 			}
 			return nil
@@ -170,6 +185,17 @@ func NewRemoveSchedulerPluginCommand(env *deployer.Environment, commonOpts *opti
 				CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
 				LeaderElection:         commonOpts.Replicas > 1,
 				LeaderElectionResource: commonOpts.SchedLeaderElectResource,
+				LeaseDuration:          commonOpts.SchedLeaseDuration,
+				RenewDeadline:          commonOpts.SchedRenewDeadline,
+				RetryPeriod:            commonOpts.SchedRetryPeriod,
+				HighAvailability:       commonOpts.SchedHighAvailability,
+				PriorityClassName:      commonOpts.SchedPriorityClassName,
+				SchedulerResources:     commonOpts.SchedResources,
+				ControllerResources:    commonOpts.SchedControllerResources,
+				Patches:                commonOpts.Patches[manifests.ComponentSchedulerPlugin],
+				ImageMirrors:           commonOpts.ImageMirrors,
+				ImagePullSecret:        commonOpts.ImagePullSecret,
+				Metrics:                commonOpts.Metrics,
 			})
 		},
 		Args: cobra.NoArgs,
@@ -206,8 +232,11 @@ func NewRemoveTopologyUpdaterCommand(env *deployer.Environment, commonOpts *opti
 				WaitCompletion:      commonOpts.WaitCompletion,
 				RTEConfigData:       commonOpts.RTEConfigData,
 				DaemonSet:           options.ForDaemonSet(commonOpts),
+				Patches:             commonOpts.Patches[updaters.ComponentFromType(commonOpts.UpdaterType)],
+				ImageMirrors:        commonOpts.ImageMirrors,
 				EnableCRIHooks:      commonOpts.UpdaterCRIHooksEnable,
 				CustomSELinuxPolicy: commonOpts.UpdaterCustomSELinuxPolicy,
+				NodePoolNamespace:   commonOpts.NodePoolNamespace,
 			})
 		},
 		Args: cobra.NoArgs,
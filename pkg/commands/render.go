@@ -24,12 +24,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	selinuxassets "github.com/k8stopologyawareschedwg/deployer/pkg/assets/selinux"
+	deploypkg "github.com/k8stopologyawareschedwg/deployer/pkg/deploy"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/api"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform/detect"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
-	apimanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/api"
-	schedmanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
@@ -39,6 +41,9 @@ func NewRenderCommand(env *deployer.Environment, commonOpts *options.Options) *c
 		Use:   "render",
 		Short: "render all the manifests",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := detectRenderPlatform(env, commonOpts); err != nil {
+				return err
+			}
 			if commonOpts.UserPlatform == platform.Unknown {
 				return fmt.Errorf("must explicitly select a cluster platform")
 			}
@@ -46,6 +51,7 @@ func NewRenderCommand(env *deployer.Environment, commonOpts *options.Options) *c
 		},
 		Args: cobra.NoArgs,
 	}
+	render.PersistentFlags().BoolVar(&commonOpts.RenderFromCluster, "from-cluster", false, "detect the platform, its version and the matching images from the cluster.")
 	render.AddCommand(NewRenderAPICommand(env, commonOpts, opts))
 	render.AddCommand(NewRenderSchedulerPluginCommand(env, commonOpts, opts))
 	render.AddCommand(NewRenderTopologyUpdaterCommand(env, commonOpts, opts))
@@ -58,19 +64,19 @@ func NewRenderAPICommand(env *deployer.Environment, commonOpts *options.Options,
 		Use:   "api",
 		Short: "render the APIs needed for topology-aware-scheduling",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := detectRenderPlatform(env, commonOpts); err != nil {
+				return err
+			}
 			if commonOpts.UserPlatform == platform.Unknown {
 				return fmt.Errorf("must explicitly select a cluster platform")
 			}
-			apiManifests, err := apimanifests.NewWithOptions(options.Render{
+			apiObjs, err := api.GetManifests(options.API{
 				Platform: commonOpts.UserPlatform,
+				Patches:  commonOpts.Patches[manifests.ComponentAPI],
 			})
 			if err != nil {
 				return err
 			}
-			apiObjs, err := apiManifests.Render()
-			if err != nil {
-				return err
-			}
 			return manifests.RenderObjects(apiObjs.ToObjects(), os.Stdout)
 		},
 		Args: cobra.NoArgs,
@@ -83,6 +89,9 @@ func NewRenderSchedulerPluginCommand(env *deployer.Environment, commonOpts *opti
 		Use:   "scheduler-plugin",
 		Short: "render the scheduler plugin needed for topology-aware-scheduling",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := detectRenderPlatform(env, commonOpts); err != nil {
+				return err
+			}
 			if commonOpts.UserPlatform == platform.Unknown {
 				return fmt.Errorf("must explicitly select a cluster platform")
 			}
@@ -92,15 +101,9 @@ func NewRenderSchedulerPluginCommand(env *deployer.Environment, commonOpts *opti
 				return err
 			}
 
-			schedManifests, err := schedmanifests.NewWithOptions(options.Render{
-				Platform:  commonOpts.UserPlatform,
-				Namespace: namespace,
-			})
-			if err != nil {
-				return err
-			}
-
 			renderOpts := options.Scheduler{
+				Platform:               commonOpts.UserPlatform,
+				Flavor:                 commonOpts.ClusterFlavor,
 				Replicas:               int32(commonOpts.Replicas),
 				PullIfNotPresent:       commonOpts.PullIfNotPresent,
 				CacheResyncPeriod:      commonOpts.SchedResyncPeriod,
@@ -108,8 +111,22 @@ func NewRenderSchedulerPluginCommand(env *deployer.Environment, commonOpts *opti
 				CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
 				LeaderElection:         commonOpts.Replicas > 1,
 				LeaderElectionResource: commonOpts.SchedLeaderElectResource,
+				LeaseDuration:          commonOpts.SchedLeaseDuration,
+				RenewDeadline:          commonOpts.SchedRenewDeadline,
+				RetryPeriod:            commonOpts.SchedRetryPeriod,
+				HighAvailability:       commonOpts.SchedHighAvailability,
+				PriorityClassName:      commonOpts.SchedPriorityClassName,
+				SchedulerResources:     commonOpts.SchedResources,
+				ControllerResources:    commonOpts.SchedControllerResources,
+				Patches:                commonOpts.Patches[manifests.ComponentSchedulerPlugin],
+				Images:                 commonOpts.Images,
+				Metrics:                commonOpts.Metrics,
+				NetworkPolicies:        commonOpts.NetworkPolicies,
+				ImageMirrors:           commonOpts.ImageMirrors,
+				ImagePullSecret:        commonOpts.ImagePullSecret,
+				ImagePullSecretSource:  commonOpts.ImagePullSecretSource,
 			}
-			schedObjs, err := schedManifests.Render(env.Log, renderOpts)
+			schedObjs, err := sched.GetManifests(env.Log, renderOpts, namespace)
 			if err != nil {
 				return err
 			}
@@ -125,6 +142,9 @@ func NewRenderTopologyUpdaterCommand(env *deployer.Environment, commonOpts *opti
 		Use:   "topology-updater",
 		Short: "render the topology updater needed for topology-aware-scheduling",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := detectRenderPlatform(env, commonOpts); err != nil {
+				return err
+			}
 			if commonOpts.UserPlatform == platform.Unknown {
 				return fmt.Errorf("must explicitly select a cluster platform")
 			}
@@ -146,13 +166,20 @@ func makeUpdaterObjects(commonOpts *options.Options) ([]client.Object, string, e
 	}
 
 	opts := options.Updater{
-		PlatformVersion:     commonOpts.UserPlatformVersion,
-		Platform:            commonOpts.UserPlatform,
-		RTEConfigData:       commonOpts.RTEConfigData,
-		DaemonSet:           options.ForDaemonSet(commonOpts),
-		EnableCRIHooks:      commonOpts.UpdaterCRIHooksEnable,
-		CustomSELinuxPolicy: commonOpts.UpdaterCustomSELinuxPolicy,
+		PlatformVersion:       commonOpts.UserPlatformVersion,
+		Platform:              commonOpts.UserPlatform,
+		RTEConfigData:         commonOpts.RTEConfigData,
+		DaemonSet:             options.ForDaemonSet(commonOpts),
+		Patches:               commonOpts.Patches[updaters.ComponentFromType(commonOpts.UpdaterType)],
+		ImageMirrors:          commonOpts.ImageMirrors,
+		ImagePullSecretSource: commonOpts.ImagePullSecretSource,
+		Metrics:               commonOpts.Metrics,
+		NetworkPolicies:       commonOpts.NetworkPolicies,
+		EnableCRIHooks:        commonOpts.UpdaterCRIHooksEnable,
+		CustomSELinuxPolicy:   commonOpts.UpdaterCustomSELinuxPolicy,
+		NodePoolNamespace:     commonOpts.NodePoolNamespace,
 	}
+	updaters.SetupNamespaceMonitoring(ns, opts)
 	objs, err := updaters.GetObjects(opts, commonOpts.UpdaterType, namespace)
 	if err != nil {
 		return nil, namespace, err
@@ -164,16 +191,17 @@ func makeUpdaterObjects(commonOpts *options.Options) ([]client.Object, string, e
 func RenderManifests(env *deployer.Environment, commonOpts *options.Options) error {
 	var objs []client.Object
 
-	apiManifests, err := apimanifests.NewWithOptions(options.Render{
+	if commonOpts.ImagePullSecretFrom.Name != "" {
+		env.Log.Info("the image pull secret is cloned only when deploying, not rendered", "source", commonOpts.ImagePullSecretFrom.String())
+	}
+
+	apiObjs, err := api.GetManifests(options.API{
 		Platform: commonOpts.UserPlatform,
+		Patches:  commonOpts.Patches[manifests.ComponentAPI],
 	})
 	if err != nil {
 		return err
 	}
-	apiObjs, err := apiManifests.Render()
-	if err != nil {
-		return err
-	}
 	objs = append(objs, apiObjs.ToObjects()...)
 
 	updaterObjs, updaterNs, err := makeUpdaterObjects(commonOpts)
@@ -182,15 +210,9 @@ func RenderManifests(env *deployer.Environment, commonOpts *options.Options) err
 	}
 	objs = append(objs, updaterObjs...)
 
-	schedManifests, err := schedmanifests.NewWithOptions(options.Render{
-		Platform:  commonOpts.UserPlatform,
-		Namespace: updaterNs,
-	})
-	if err != nil {
-		return err
-	}
-
 	schedRenderOpts := options.Scheduler{
+		Platform:               commonOpts.UserPlatform,
+		Flavor:                 commonOpts.ClusterFlavor,
 		Replicas:               int32(commonOpts.Replicas),
 		PullIfNotPresent:       commonOpts.PullIfNotPresent,
 		ProfileName:            commonOpts.SchedProfileName,
@@ -201,9 +223,23 @@ func RenderManifests(env *deployer.Environment, commonOpts *options.Options) err
 		CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
 		LeaderElection:         commonOpts.Replicas > 1,
 		LeaderElectionResource: commonOpts.SchedLeaderElectResource,
+		LeaseDuration:          commonOpts.SchedLeaseDuration,
+		RenewDeadline:          commonOpts.SchedRenewDeadline,
+		RetryPeriod:            commonOpts.SchedRetryPeriod,
+		HighAvailability:       commonOpts.SchedHighAvailability,
+		PriorityClassName:      commonOpts.SchedPriorityClassName,
+		SchedulerResources:     commonOpts.SchedResources,
+		ControllerResources:    commonOpts.SchedControllerResources,
+		Patches:                commonOpts.Patches[manifests.ComponentSchedulerPlugin],
+		Images:                 commonOpts.Images,
+		Metrics:                commonOpts.Metrics,
+		NetworkPolicies:        commonOpts.NetworkPolicies,
+		ImageMirrors:           commonOpts.ImageMirrors,
+		ImagePullSecret:        commonOpts.ImagePullSecret,
+		ImagePullSecretSource:  commonOpts.ImagePullSecretSource,
 	}
 
-	schedObjs, err := schedManifests.Render(env.Log, schedRenderOpts)
+	schedObjs, err := sched.GetManifests(env.Log, schedRenderOpts, updaterNs)
 	if err != nil {
 		return err
 	}
@@ -217,6 +253,9 @@ func NewRenderPolicyCommand(env *deployer.Environment, commonOpts *options.Optio
 		Use:   "policy",
 		Short: "render the SELinux policy needed for topology-aware-scheduling",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := detectRenderPlatform(env, commonOpts); err != nil {
+				return err
+			}
 			if commonOpts.UserPlatform != platform.OpenShift {
 				return fmt.Errorf("must explicitly select the OpenShift platform")
 			}
@@ -231,3 +270,33 @@ func NewRenderPolicyCommand(env *deployer.Environment, commonOpts *options.Optio
 	}
 	return render
 }
+
+// detectRenderPlatform detects the platform to render for, and selects the images matching its version, if requested.
+// The platform and version given by the user take precedence over the detected ones.
+func detectRenderPlatform(env *deployer.Environment, commonOpts *options.Options) error {
+	if !commonOpts.RenderFromCluster {
+		return nil
+	}
+	if err := env.EnsureClient(); err != nil {
+		return err
+	}
+
+	platDetect, reason, _ := detect.FindPlatform(env.Ctx, commonOpts.UserPlatform)
+	commonOpts.ClusterPlatform = platDetect.Discovered
+	if commonOpts.ClusterPlatform == platform.Unknown {
+		return fmt.Errorf("cannot autodetect the platform, and no platform given")
+	}
+	versionDetect, source, _ := detect.FindVersion(env.Ctx, platDetect.Discovered, commonOpts.UserPlatformVersion)
+	commonOpts.ClusterVersion = versionDetect.Discovered
+	if commonOpts.ClusterVersion == platform.MissingVersion {
+		return fmt.Errorf("cannot autodetect the platform version, and no version given")
+	}
+	env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
+
+	commonOpts.UserPlatform = commonOpts.ClusterPlatform
+	commonOpts.UserPlatformVersion = commonOpts.ClusterVersion
+	if err := deploypkg.DiscoverAPIServer(env, commonOpts); err != nil {
+		return err
+	}
+	return deploypkg.SelectImages(env.Log, commonOpts)
+}
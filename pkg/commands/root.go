@@ -18,6 +18,7 @@ package commands
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strings"
 	"time"
@@ -26,14 +27,22 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	deploypkg "github.com/k8stopologyawareschedwg/deployer/pkg/deploy"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform/detect"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/wait"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	rtemanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/rte"
 	schedmanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/sched"
+	rteupdate "github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate/rte"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/patches"
 )
 
 type internalOptions struct {
@@ -44,6 +53,20 @@ type internalOptions struct {
 	schedCacheParamsConfigFile  string
 	updaterSCCVersion           string
 	plat                        string
+	flavor                      string
+	nodeSelector                string
+	tolerations                 string
+	patches                     []string
+	rteResources                string
+	rtePauseResources           string
+	nfdResources                string
+	schedResources              string
+	schedControllerResources    string
+	imageMirrors                []string
+	metricsMonitor              string
+	imagePullSecretFrom         string
+	updaterMetricsTLS           string
+	networkPolicies             string
 }
 
 func ShowHelp(cmd *cobra.Command, args []string) error {
@@ -93,11 +116,31 @@ func NewRootCommand(env *deployer.Environment, extraCmds ...NewCommandFunc) *cob
 func InitFlags(flags *pflag.FlagSet, commonOpts *options.Options, internalOpts *internalOptions) {
 	flags.IntVarP(&internalOpts.verbose, "verbose", "v", 1, "set the tool verbosity.")
 	flags.StringVarP(&internalOpts.plat, "platform", "P", "", "platform kind:version to deploy on (example kubernetes:v1.22)")
+	flags.StringVar(&internalOpts.flavor, "flavor", "", fmt.Sprintf("kubernetes distribution to deploy on, autodetected if empty. One of: %s", flavorNames()))
 	flags.StringVar(&internalOpts.rteConfigFile, "rte-config-file", "", "inject rte configuration reading from this file.")
 	flags.StringVar(&internalOpts.schedScoringStratConfigFile, "sched-scoring-strat-config-file", "", "inject scheduler scoring strategy configuration reading from this file.")
 	flags.StringVar(&internalOpts.schedCacheParamsConfigFile, "sched-cache-params-config-file", "", "inject scheduler fine cache params configuration reading from this file.")
 	flags.IntVarP(&internalOpts.replicas, "replicas", "R", 1, "set the replica value - where relevant.")
 	flags.StringVar(&internalOpts.updaterSCCVersion, "updater-scc", "v2", "select the SecurityContextConstraint version to use. v2 by default")
+	flags.StringVar(&internalOpts.nodeSelector, "node-selector", "", "label selector (e.g. \"foo=bar,baz in (a,b)\") of the nodes to validate and to run the updater on. Worker nodes by default.")
+	flags.StringArrayVar(&internalOpts.patches, "patch", nil, "patch the rendered objects of a component (api, sched, rte, nfd) with the patches in FILE, using the \"component:FILE\" syntax. Can be repeated.")
+	flags.StringVar(&internalOpts.tolerations, "tolerations", "", "comma-separated tolerations for the updater pods, using the taint syntax (e.g. \"key=value:NoSchedule,key:NoExecute\").")
+	flags.StringArrayVar(&internalOpts.imageMirrors, "image-registry-mirror", nil, "pull the container images from a mirror, using the \"source=mirror\" syntax (e.g. \"registry.k8s.io=mirror.example.com/k8s\"). Sources and mirrors are registries or repository prefixes. Can be repeated.")
+	flags.StringVar(&commonOpts.ImagePullSecret, "image-pull-secret", "", "name of the image pull secret of all the component pods. The secret must exist in the component namespaces, unless --image-pull-secret-from is given.")
+	flags.StringVar(&internalOpts.imagePullSecretFrom, "image-pull-secret-from", "", "clone the existing \"namespace/name\" secret as --image-pull-secret in the component namespaces when deploying.")
+	flags.StringVar(&commonOpts.OnIncompatibleImages, "on-incompatible-images", string(deploypkg.DefaultIncompatibleImagesPolicy), "what to do if no bundled image set supports the cluster version when deploying or rendering --from-cluster: warn (use the default images), fail.")
+	flags.StringVar(&internalOpts.metricsMonitor, "metrics-monitor", "", "render a Prometheus Operator monitor for the RTE and scheduler metrics: servicemonitor, podmonitor. Empty disables the scraping.")
+	flags.StringVar(&commonOpts.Metrics.Stack, "metrics-monitoring-stack", manifests.MonitoringStackCluster, "OpenShift monitoring stack scraping the metrics: cluster, user-workload.")
+	flags.BoolVar(&commonOpts.Metrics.InsecureSkipVerify, "metrics-insecure-skip-verify", false, "let the monitors scrape the HTTPS metrics endpoints without verifying their certificate, when no CA is available to verify it.")
+	flags.StringVar(&commonOpts.Metrics.Scraper, "metrics-scraper", "", "\"namespace/name\" of the service account of the prometheus scraping the metrics. Empty uses the default of the platform and monitoring stack.")
+	flags.StringVar(&internalOpts.networkPolicies, "network-policies", "on", "render the network policies isolating the component pods: on, off. Turn off for the CNIs which don't enforce them.")
+	flags.StringSliceVar(&commonOpts.NetworkPolicies.APIServerAddresses, "apiserver-address", nil, "IPs or CIDRs of the API server endpoints the network policies allow. Discovered from the default/kubernetes EndpointSlice when deploying if neither addresses nor ports are given, any address otherwise.")
+	flags.Int32SliceVar(&commonOpts.NetworkPolicies.APIServerPorts, "apiserver-port", nil, fmt.Sprintf("ports of the API server endpoints the network policies allow. Discovered like --apiserver-address, %d otherwise.", manifests.DefaultAPIServerPort))
+	flags.StringVar(&internalOpts.rteResources, "rte-resources", "", "resources of the RTE container (e.g. \"requests.cpu=50m,requests.memory=128Mi,limits.memory=256Mi\"). Empty uses the defaults.")
+	flags.StringVar(&internalOpts.rtePauseResources, "rte-pause-resources", "", "resources of the RTE shared pool container, same syntax as --rte-resources. Empty uses the defaults.")
+	flags.StringVar(&internalOpts.nfdResources, "nfd-resources", "", "resources of the NFD topology updater container, same syntax as --rte-resources. Empty uses the defaults.")
+	flags.StringVar(&internalOpts.schedResources, "sched-resources", "", "resources of the scheduler container, same syntax as --rte-resources. Empty uses the defaults.")
+	flags.StringVar(&internalOpts.schedControllerResources, "sched-controller-resources", "", "resources of the scheduler controller container, same syntax as --rte-resources. Empty uses the defaults.")
 
 	flags.DurationVarP(&commonOpts.WaitInterval, "wait-interval", "E", 2*time.Second, "wait interval.")
 	flags.DurationVarP(&commonOpts.WaitTimeout, "wait-timeout", "T", 2*time.Minute, "wait timeout.")
@@ -108,12 +151,21 @@ func InitFlags(flags *pflag.FlagSet, commonOpts *options.Options, internalOpts *
 	flags.BoolVar(&commonOpts.UpdaterCRIHooksEnable, "updater-cri-hooks-enable", false, "toggle installation of CRI hooks on the updater side.")
 	flags.BoolVar(&commonOpts.UpdaterCustomSELinuxPolicy, "updater-custom-selinux-policy", true, "toggle installation of selinux policy in the legacy policy on the updater side. on by default")
 	flags.DurationVar(&commonOpts.UpdaterSyncPeriod, "updater-sync-period", manifests.DefaultUpdaterSyncPeriod, "tune the updater synchronization (nrt update) interval. Use 0 to disable.")
+	flags.IntVar(&commonOpts.UpdaterMetricsPort, "updater-metrics-port", rteupdate.DefaultMetricsPort, "port of the updater metrics endpoint. RTE only.")
+	flags.StringVar(&internalOpts.updaterMetricsTLS, "updater-metrics-tls", string(options.MetricsTLSOff), "serve the updater metrics over HTTPS: off, service-ca (certificate generated by the OpenShift service CA operator), secret:NAME (existing kubernetes.io/tls secret in the updater namespace). RTE only.")
+	flags.StringVar(&commonOpts.UpdaterKubeletConfigFile, "updater-kubelet-config-file", "", "name of the kubelet configuration file the updater reads, in the kubelet configuration directory of the flavor. Empty uses the flavor default.")
 	flags.IntVar(&commonOpts.UpdaterVerbose, "updater-verbose", manifests.DefaultUpdaterVerbose, "set the updater verbosiness.")
 	flags.StringVar(&commonOpts.SchedProfileName, "sched-profile-name", schedmanifests.DefaultProfileName, "inject scheduler profile name.")
 	flags.DurationVar(&commonOpts.SchedResyncPeriod, "sched-resync-period", schedmanifests.DefaultResyncPeriod, "inject scheduler resync period.")
 	flags.IntVar(&commonOpts.SchedVerbose, "sched-verbose", schedmanifests.DefaultVerbose, "set the scheduler verbosiness.")
 	flags.BoolVar(&commonOpts.SchedCtrlPlaneAffinity, "sched-ctrlplane-affinity", schedmanifests.DefaultCtrlPlaneAffinity, "toggle the scheduler control plane affinity.")
+	flags.StringVar(&commonOpts.NodePoolNamespace, "hypershift-nodepool-namespace", rtemanifests.DefaultNodePoolNamespace, "management cluster namespace of the HyperShift NodePool ConfigMaps.")
 	flags.StringVar(&commonOpts.SchedLeaderElectResource, "sched-leader-elect-resource", schedmanifests.DefaultLeaderElectResource, "leader election resource namespaced name \"namespace/name\"")
+	flags.DurationVar(&commonOpts.SchedLeaseDuration, "sched-leader-elect-lease-duration", 0, "leader election lease duration. 0 uses the scheduler default.")
+	flags.DurationVar(&commonOpts.SchedRenewDeadline, "sched-leader-elect-renew-deadline", 0, "leader election renew deadline. 0 uses the scheduler default.")
+	flags.DurationVar(&commonOpts.SchedRetryPeriod, "sched-leader-elect-retry-period", 0, "leader election retry period. 0 uses the scheduler default.")
+	flags.BoolVar(&commonOpts.SchedHighAvailability, "sched-ha", false, "enable the scheduler high availability mode: PodDisruptionBudget, spreading of the replicas across nodes and zones, priority class. Requires at least 2 replicas.")
+	flags.StringVar(&commonOpts.SchedPriorityClassName, "sched-priority-class", "", fmt.Sprintf("priority class of the scheduler pods. Empty means none, or %q in high availability mode.", schedmanifests.DefaultPriorityClassName))
 }
 
 func PostSetupOptions(env *deployer.Environment, commonOpts *options.Options, internalOpts *internalOptions) error {
@@ -127,6 +179,87 @@ func PostSetupOptions(env *deployer.Environment, commonOpts *options.Options, in
 	}
 	commonOpts.UpdaterSCCVersion = options.SCCVersion(internalOpts.updaterSCCVersion)
 
+	nodeSelector, err := options.ParseNodeSelector(internalOpts.nodeSelector)
+	if err != nil {
+		return fmt.Errorf("invalid node selector %q: %w", internalOpts.nodeSelector, err)
+	}
+	commonOpts.NodeSelector = nodeSelector
+
+	tolerations, err := options.ParseTolerations(internalOpts.tolerations)
+	if err != nil {
+		return fmt.Errorf("invalid tolerations %q: %w", internalOpts.tolerations, err)
+	}
+	commonOpts.Tolerations = tolerations
+
+	err = parseResources(commonOpts, internalOpts)
+	if err != nil {
+		return err
+	}
+
+	commonOpts.Patches, err = loadPatches(internalOpts.patches)
+	if err != nil {
+		return err
+	}
+
+	if _, err := deploypkg.ParseIncompatibleImagesPolicy(commonOpts.OnIncompatibleImages); err != nil {
+		return err
+	}
+
+	commonOpts.UpdaterMetricsTLS, err = options.ParseMetricsTLS(internalOpts.updaterMetricsTLS)
+	if err != nil {
+		return err
+	}
+	if commonOpts.UpdaterMetricsPort < 1 || commonOpts.UpdaterMetricsPort > 65535 {
+		return fmt.Errorf("invalid --updater-metrics-port %d", commonOpts.UpdaterMetricsPort)
+	}
+	if commonOpts.UpdaterType != updaters.RTE && (commonOpts.UpdaterMetricsPort != rteupdate.DefaultMetricsPort || commonOpts.UpdaterMetricsTLS.Enabled()) {
+		return fmt.Errorf("the updater metrics endpoint is configurable only for the %s updater", updaters.RTE)
+	}
+
+	if strings.ContainsRune(commonOpts.UpdaterKubeletConfigFile, '/') {
+		return fmt.Errorf("invalid --updater-kubelet-config-file %q: expected a file name", commonOpts.UpdaterKubeletConfigFile)
+	}
+
+	err = parseNetworkPolicies(commonOpts, internalOpts)
+	if err != nil {
+		return err
+	}
+
+	commonOpts.Metrics.Monitor, err = manifests.ParseMonitorKind(internalOpts.metricsMonitor)
+	if err != nil {
+		return err
+	}
+	if _, err := manifests.ParseMonitoringStack(commonOpts.Metrics.Stack); err != nil {
+		return err
+	}
+	if commonOpts.Metrics.Scraper != "" {
+		namespace, name, ok := strings.Cut(commonOpts.Metrics.Scraper, "/")
+		if !ok || namespace == "" || name == "" {
+			return fmt.Errorf("invalid --metrics-scraper %q: expected \"namespace/name\"", commonOpts.Metrics.Scraper)
+		}
+	}
+
+	commonOpts.ImagePullSecretFrom = types.NamespacedName{}
+	if internalOpts.imagePullSecretFrom != "" {
+		if commonOpts.ImagePullSecret == "" {
+			return fmt.Errorf("--image-pull-secret-from requires --image-pull-secret")
+		}
+		namespace, name, ok := strings.Cut(internalOpts.imagePullSecretFrom, "/")
+		if !ok || namespace == "" || name == "" {
+			return fmt.Errorf("invalid --image-pull-secret-from %q: expected \"namespace/name\"", internalOpts.imagePullSecretFrom)
+		}
+		commonOpts.ImagePullSecretFrom = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+
+	commonOpts.ImageMirrors = nil
+	for _, spec := range internalOpts.imageMirrors {
+		mr, err := images.ParseMirror(spec)
+		if err != nil {
+			return err
+		}
+		commonOpts.ImageMirrors = append(commonOpts.ImageMirrors, mr)
+	}
+
 	if internalOpts.replicas < 0 {
 		err := env.EnsureClient()
 		if err != nil {
@@ -139,7 +272,11 @@ func PostSetupOptions(env *deployer.Environment, commonOpts *options.Options, in
 			return err
 		}
 		commonOpts.Replicas = info.NodeCount
-		env.Log.V(3).Info("autodetected control plane nodes, set replicas accordingly", "controlPlaneNodes", info.NodeCount)
+		if info.NodeCount == 0 {
+			commonOpts.Replicas = schedmanifests.DefaultReplicasHosted
+			env.Log.Info("no control plane nodes found, using the hosted cluster defaults", "replicas", commonOpts.Replicas)
+		}
+		env.Log.V(3).Info("autodetected control plane nodes, set replicas accordingly", "controlPlaneNodes", info.NodeCount, "replicas", commonOpts.Replicas)
 	} else {
 		commonOpts.Replicas = internalOpts.replicas
 	}
@@ -159,6 +296,17 @@ func PostSetupOptions(env *deployer.Environment, commonOpts *options.Options, in
 		commonOpts.UserPlatformVersion, _ = platform.ParseVersion(fields[1])
 	}
 
+	commonOpts.UserFlavor = ""
+	commonOpts.ClusterFlavor = platform.FlavorGeneric
+	if internalOpts.flavor != "" {
+		flavor, ok := platform.ParseFlavor(internalOpts.flavor)
+		if !ok {
+			return fmt.Errorf("unsupported flavor: %q", internalOpts.flavor)
+		}
+		commonOpts.UserFlavor = flavor
+		commonOpts.ClusterFlavor = flavor
+	}
+
 	if internalOpts.rteConfigFile != "" {
 		data, err := os.ReadFile(internalOpts.rteConfigFile)
 		if err != nil {
@@ -187,9 +335,84 @@ func PostSetupOptions(env *deployer.Environment, commonOpts *options.Options, in
 	return validateUpdaterType(commonOpts.UpdaterType)
 }
 
+func loadPatches(specs []string) (map[string][]patches.Patch, error) {
+	ret := make(map[string][]patches.Patch)
+	for _, spec := range specs {
+		component, path, err := patches.ParseSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		switch component {
+		case manifests.ComponentAPI, manifests.ComponentSchedulerPlugin, manifests.ComponentResourceTopologyExporter, manifests.ComponentNodeFeatureDiscovery:
+		default:
+			return nil, fmt.Errorf("unsupported patch component %q", component)
+		}
+		pts, err := patches.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		ret[component] = append(ret[component], pts...)
+	}
+	return ret, nil
+}
+
+func flavorNames() string {
+	var names []string
+	for _, fl := range platform.Flavors() {
+		names = append(names, strings.ToLower(fl.String()))
+	}
+	return strings.Join(names, ", ")
+}
+
 func validateUpdaterType(updaterType string) error {
 	if updaterType != updaters.RTE && updaterType != updaters.NFD {
 		return fmt.Errorf("%q is invalid updater type", updaterType)
 	}
 	return nil
 }
+
+func parseNetworkPolicies(commonOpts *options.Options, internalOpts *internalOptions) error {
+	switch internalOpts.networkPolicies {
+	case "on":
+		commonOpts.NetworkPolicies.Disabled = false
+	case "off":
+		commonOpts.NetworkPolicies.Disabled = true
+	default:
+		return fmt.Errorf("invalid --network-policies %q: expected \"on\" or \"off\"", internalOpts.networkPolicies)
+	}
+	for _, addr := range commonOpts.NetworkPolicies.APIServerAddresses {
+		if net.ParseIP(addr) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(addr); err != nil {
+			return fmt.Errorf("invalid --apiserver-address %q: expected an IP or a CIDR", addr)
+		}
+	}
+	for _, port := range commonOpts.NetworkPolicies.APIServerPorts {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("invalid --apiserver-port %d", port)
+		}
+	}
+	return nil
+}
+
+func parseResources(commonOpts *options.Options, internalOpts *internalOptions) error {
+	for _, item := range []struct {
+		flag string
+		spec string
+		dest *corev1.ResourceRequirements
+	}{
+		{"rte-resources", internalOpts.rteResources, &commonOpts.RTEResources},
+		{"rte-pause-resources", internalOpts.rtePauseResources, &commonOpts.RTEPauseResources},
+		{"nfd-resources", internalOpts.nfdResources, &commonOpts.NFDResources},
+		{"sched-resources", internalOpts.schedResources, &commonOpts.SchedResources},
+		{"sched-controller-resources", internalOpts.schedControllerResources, &commonOpts.SchedControllerResources},
+	} {
+		res, err := options.ParseResources(item.spec)
+		if err != nil {
+			return fmt.Errorf("invalid --%s %q: %w", item.flag, item.spec, err)
+		}
+		*item.dest = res
+	}
+	return nil
+}
@@ -20,14 +20,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	machineconfigv1 "github.com/openshift/api/machineconfiguration/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/clientutil"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/clientutil/nodes"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform/detect"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
+	schedmanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/validator"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/validator/report"
 )
 
 type ValidateOutputMode int
@@ -37,13 +52,34 @@ const (
 	ValidateOutputText
 	ValidateOutputJSON
 	ValidateOutputLog
+	ValidateOutputJUnit
+	ValidateOutputSARIF
+)
+
+const (
+	ValidateOutputFormatText  = "text"
+	ValidateOutputFormatJSON  = "json"
+	ValidateOutputFormatJUnit = "junit"
+	ValidateOutputFormatSARIF = "sarif"
 )
 
 type validateOptions struct {
-	outputMode ValidateOutputMode
-	jsonOutput bool
+	outputMode  ValidateOutputMode
+	jsonOutput  bool
+	output      string
+	policy      string
+	failOn      string
+	consistency bool
+	postDeploy  bool
+	testPod     bool
+	testPodNS   string
+	testPodImg  string
 }
 
+const (
+	ValidateFailOnNone = "none"
+)
+
 func NewValidateCommand(env *deployer.Environment, commonOpts *options.Options) *cobra.Command {
 	opts := &validateOptions{}
 	validate := &cobra.Command{
@@ -54,7 +90,15 @@ func NewValidateCommand(env *deployer.Environment, commonOpts *options.Options)
 		},
 		Args: cobra.NoArgs,
 	}
-	validate.Flags().BoolVarP(&opts.jsonOutput, "json", "J", false, "output JSON, not text.")
+	validate.Flags().BoolVarP(&opts.jsonOutput, "json", "J", false, "output JSON, not text. Shortcut for --output=json.")
+	validate.Flags().StringVarP(&opts.output, "output", "o", ValidateOutputFormatText, "output format: text, json, junit, sarif.")
+	validate.Flags().StringVar(&opts.policy, "policy", validator.DefaultPolicyName, fmt.Sprintf("validation policy: builtin profile name (%s) or path to a policy file.", strings.Join(validator.ProfileNames(), ", ")))
+	validate.Flags().BoolVar(&opts.consistency, "consistency", false, "group the nodes by their kubelet configuration and report the outliers.")
+	validate.Flags().BoolVar(&opts.postDeploy, "post-deploy", false, "verify the deployed stack works, instead of the cluster configuration.")
+	validate.Flags().BoolVar(&opts.testPod, "test-pod", false, "with --post-deploy, run a guaranteed test pod through the topology-aware scheduler.")
+	validate.Flags().StringVar(&opts.testPodNS, "test-pod-namespace", validator.DefaultTestPodNamespace, "namespace to run the test pod in.")
+	validate.Flags().StringVar(&opts.testPodImg, "test-pod-image", images.Get().Pause, "image of the test pod. Default can be overridden with TAS_PAUSE_IMAGE.")
+	validate.Flags().StringVar(&opts.failOn, "fail-on", ValidateFailOnNone, "exit with error if any finding has at least this severity: none, warning, error.")
 	return validate
 }
 
@@ -62,53 +106,267 @@ func validatePostSetupOptions(opts *validateOptions) error {
 	if opts.outputMode != ValidateOutputNone {
 		return nil // nothing to do!
 	}
-	opts.outputMode = ValidateOutputText
 	if opts.jsonOutput {
 		opts.outputMode = ValidateOutputJSON
+		return nil
+	}
+	switch strings.ToLower(opts.output) {
+	case "", ValidateOutputFormatText:
+		opts.outputMode = ValidateOutputText
+	case ValidateOutputFormatJSON:
+		opts.outputMode = ValidateOutputJSON
+	case ValidateOutputFormatJUnit:
+		opts.outputMode = ValidateOutputJUnit
+	case ValidateOutputFormatSARIF:
+		opts.outputMode = ValidateOutputSARIF
+	default:
+		return fmt.Errorf("unsupported output format %q", opts.output)
 	}
 	return nil
 }
 
+type validationSummary struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Info     int `json:"info"`
+}
+
 type validationOutput struct {
-	Success bool                         `json:"success"`
-	Errors  []validator.ValidationResult `json:"errors,omitempty"`
+	Success  bool                         `json:"success"`
+	Errors   []validator.ValidationResult `json:"errors,omitempty"`
+	Warnings []validator.ValidationResult `json:"warnings,omitempty"`
+	Info     []validator.ValidationResult `json:"info,omitempty"`
+	Summary  validationSummary            `json:"summary"`
+
+	Consistency *validator.ConsistencyReport `json:"consistency,omitempty"`
+}
+
+func newValidationOutput(items []validator.ValidationResult, consistency *validator.ConsistencyReport) validationOutput {
+	out := validationOutput{
+		Consistency: consistency,
+	}
+	for _, item := range items {
+		switch item.GetSeverity() {
+		case validator.SeverityWarning:
+			out.Warnings = append(out.Warnings, item)
+		case validator.SeverityInfo:
+			out.Info = append(out.Info, item)
+		default:
+			out.Errors = append(out.Errors, item)
+		}
+	}
+	out.Success = (len(out.Errors) == 0)
+	out.Summary = validationSummary{
+		Errors:   len(out.Errors),
+		Warnings: len(out.Warnings),
+		Info:     len(out.Info),
+	}
+	return out
+}
+
+func parseFailOn(failOn string) (validator.Severity, bool, error) {
+	if failOn == "" || failOn == ValidateFailOnNone {
+		return "", false, nil
+	}
+	sev, ok := validator.ParseSeverity(failOn)
+	if !ok || sev == validator.SeverityInfo {
+		return "", false, fmt.Errorf("unsupported fail-on value %q", failOn)
+	}
+	return sev, true, nil
 }
 
 func validateCluster(cmd *cobra.Command, env *deployer.Environment, commonOpts *options.Options, opts *validateOptions, args []string) error {
-	// TODO
-	validatePostSetupOptions(opts)
+	if err := validatePostSetupOptions(opts); err != nil {
+		return err
+	}
 
-	err := env.EnsureClient()
+	failSev, failEnabled, err := parseFailOn(opts.failOn)
 	if err != nil {
 		return err
 	}
 
-	vd, err := validator.NewValidator(env.Log)
+	err = env.EnsureClient()
 	if err != nil {
 		return err
 	}
 
-	nodeList, err := nodes.GetWorkers(env)
+	pol, err := loadValidationPolicy(opts.policy)
 	if err != nil {
 		return err
 	}
+	env.Log.V(3).Info("validation policy", "name", pol.Name)
 
-	if _, err := vd.ValidateClusterConfig(nodeList); err != nil {
+	vd, err := validator.NewValidator(env.Log)
+	if err != nil {
 		return err
 	}
+	vd.Policy = &pol
+
+	nodeList, err := nodes.GetTargets(env, commonOpts.NodeSelector)
+	if err != nil {
+		return err
+	}
+
+	if opts.postDeploy {
+		if err := validatePostDeploy(env, commonOpts, opts, vd, nodeList); err != nil {
+			return err
+		}
+	} else {
+		if _, err := vd.ValidateClusterConfig(nodeList); err != nil {
+			return err
+		}
+		if err := validateOpenShift(env, commonOpts, vd, nodeList); err != nil {
+			return err
+		}
+	}
+
+	var consistency *validator.ConsistencyReport
+	if opts.consistency && !opts.postDeploy {
+		report := vd.ValidateKubeletConsistency()
+		consistency = &report
+	}
 
-	printValidationResults(vd.Results(), env.Log, opts.outputMode)
+	items := vd.Results()
+	switch opts.outputMode {
+	case ValidateOutputJUnit:
+		err = report.JUnit(os.Stdout, vd.Checks(), items)
+	case ValidateOutputSARIF:
+		err = report.SARIF(os.Stdout, vd.Checks(), items)
+	default:
+		printValidationResults(items, consistency, env.Log, opts.outputMode)
+	}
+	if err != nil {
+		return err
+	}
+	if failEnabled && validator.HasAtLeast(items, failSev) {
+		return fmt.Errorf("validation found issues with severity %s or higher", failSev)
+	}
 	return nil
 }
 
+func detectValidationPlatform(env *deployer.Environment, commonOpts *options.Options) platform.Platform {
+	if commonOpts.ClusterPlatform != "" && commonOpts.ClusterPlatform != platform.Unknown {
+		return commonOpts.ClusterPlatform
+	}
+	platDetect, reason, _ := detect.FindPlatform(env.Ctx, commonOpts.UserPlatform)
+	commonOpts.ClusterPlatform = platDetect.Discovered
+	env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason)
+	return commonOpts.ClusterPlatform
+}
+
+// validateOpenShift attributes the kubelet findings to the KubeletConfig or PerformanceProfile managing the node
+// and checks the MachineConfigPools rollout state. Does nothing on other platforms.
+func validateOpenShift(env *deployer.Environment, commonOpts *options.Options, vd *validator.Validator, nodeList []corev1.Node) error {
+	if detectValidationPlatform(env, commonOpts) != platform.OpenShift {
+		return nil
+	}
+	mcps := machineconfigv1.MachineConfigPoolList{}
+	if err := env.Cli.List(env.Ctx, &mcps); err != nil {
+		return err
+	}
+	kcs := machineconfigv1.KubeletConfigList{}
+	if err := env.Cli.List(env.Ctx, &kcs); err != nil {
+		return err
+	}
+	env.Log.V(3).Info("openshift machine configuration", "pools", len(mcps.Items), "kubeletConfigs", len(kcs.Items))
+	_, err := vd.ValidateOpenShiftConfig(nodeList, mcps.Items, kcs.Items)
+	return err
+}
+
+func validatePostDeploy(env *deployer.Environment, commonOpts *options.Options, opts *validateOptions, vd *validator.Validator, nodeList []corev1.Node) error {
+	if detectValidationPlatform(env, commonOpts) == platform.Unknown {
+		return fmt.Errorf("cannot autodetect the platform, and no platform given")
+	}
+
+	mf, err := schedmanifests.NewWithOptions(options.Render{
+		Platform: commonOpts.ClusterPlatform,
+	})
+	if err != nil {
+		return err
+	}
+	replicas := commonOpts.Replicas
+	if replicas <= 0 {
+		replicas = 1 // irrelevant for the checks, but must be valid
+	}
+	mf, err = mf.Render(env.Log, options.Scheduler{
+		Replicas:          int32(replicas),
+		ProfileName:       commonOpts.SchedProfileName,
+		CacheResyncPeriod: commonOpts.SchedResyncPeriod,
+	})
+	if err != nil {
+		return err
+	}
+
+	updaterDs, err := renderUpdaterDaemonSet(commonOpts)
+	if err != nil {
+		return err
+	}
+
+	pdOpts := validator.PostDeployOptions{
+		UpdaterNamespace:     updaterDs.Namespace,
+		UpdaterSelector:      updaterDs.Spec.Selector.MatchLabels,
+		SchedulerConfigMap:   client.ObjectKeyFromObject(mf.ConfigMap),
+		SchedulerProfileName: commonOpts.SchedProfileName,
+		WaitTimeout:          commonOpts.WaitTimeout,
+	}
+	if opts.testPod {
+		pdOpts.TestPod = validator.NewTestPod(opts.testPodNS, commonOpts.SchedProfileName, opts.testPodImg)
+	}
+
+	topoCli, err := clientutil.NewTopologyClient()
+	if err != nil {
+		return err
+	}
+	_, err = vd.ValidatePostDeploy(env.Ctx, env.Cli, topoCli, nodeList, pdOpts)
+	return err
+}
+
+// renderUpdaterDaemonSet returns the updater daemonset as deployed on the validated cluster
+func renderUpdaterDaemonSet(commonOpts *options.Options) (*appsv1.DaemonSet, error) {
+	_, namespace, err := updaters.SetupNamespace(commonOpts.UpdaterType)
+	if err != nil {
+		return nil, err
+	}
+	objs, err := updaters.GetObjects(options.Updater{
+		Platform:        commonOpts.ClusterPlatform,
+		PlatformVersion: commonOpts.UserPlatformVersion,
+		DaemonSet:       options.ForDaemonSet(commonOpts),
+		Patches:         commonOpts.Patches[updaters.ComponentFromType(commonOpts.UpdaterType)],
+	}, commonOpts.UpdaterType, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		if ds, ok := obj.(*appsv1.DaemonSet); ok {
+			return ds, nil
+		}
+	}
+	return nil, fmt.Errorf("no daemonset rendered for the %s updater", commonOpts.UpdaterType)
+}
+
+func loadValidationPolicy(policy string) (validator.Policy, error) {
+	if policy == "" {
+		policy = validator.DefaultPolicyName
+	}
+	if pol, ok := validator.GetProfile(policy); ok {
+		return pol, nil
+	}
+	data, err := os.ReadFile(policy)
+	if err != nil {
+		return validator.Policy{}, fmt.Errorf("policy %q is neither a builtin profile nor a readable file: %w", policy, err)
+	}
+	return validator.DecodePolicy(data)
+}
+
 // we need undecorated output, so we need to use fmt.Printf here. log packages add no value.
-func printValidationResults(items []validator.ValidationResult, logger logr.Logger, outputMode ValidateOutputMode) {
+func printValidationResults(items []validator.ValidationResult, consistency *validator.ConsistencyReport, logger logr.Logger, outputMode ValidateOutputMode) {
+	if consistency != nil {
+		printConsistencyReport(*consistency, logger, outputMode)
+	}
 	if len(items) == 0 {
 		switch outputMode {
 		case ValidateOutputJSON:
-			json.NewEncoder(os.Stdout).Encode(validationOutput{
-				Success: true,
-			})
+			json.NewEncoder(os.Stdout).Encode(newValidationOutput(items, consistency))
 		case ValidateOutputText:
 			fmt.Printf("PASSED>>: the cluster configuration looks ok!\n")
 		case ValidateOutputLog:
@@ -121,17 +379,20 @@ func printValidationResults(items []validator.ValidationResult, logger logr.Logg
 	} else {
 		switch outputMode {
 		case ValidateOutputJSON:
-			json.NewEncoder(os.Stdout).Encode(validationOutput{
-				Success: false,
-				Errors:  items,
-			})
+			json.NewEncoder(os.Stdout).Encode(newValidationOutput(items, consistency))
 		case ValidateOutputText:
 			for idx, item := range items {
-				fmt.Printf("ERROR#%03d: %s\n", idx, item.String())
+				fmt.Printf("%s#%03d: [%s] %s\n", strings.ToUpper(string(item.GetSeverity())), idx, item.RuleID, item.String())
+				if item.Target != "" {
+					fmt.Printf("  target: %s\n", item.Target)
+				}
+				if item.Remediation != "" {
+					fmt.Printf("  hint: %s\n", item.Remediation)
+				}
 			}
 		case ValidateOutputLog:
 			for idx, item := range items {
-				logger.Info("cluster configuration", "issue", idx, "description", item.String())
+				logger.Info("cluster configuration", "issue", idx, "severity", item.GetSeverity(), "rule", item.RuleID, "description", item.String(), "target", item.Target, "remediation", item.Remediation)
 			}
 		case ValidateOutputNone:
 			fallthrough
@@ -140,3 +401,28 @@ func printValidationResults(items []validator.ValidationResult, logger logr.Logg
 		}
 	}
 }
+
+func printConsistencyReport(report validator.ConsistencyReport, logger logr.Logger, outputMode ValidateOutputMode) {
+	switch outputMode {
+	case ValidateOutputText:
+		for idx, pool := range report.Pools {
+			fmt.Printf("POOL#%03d: %s nodes=%s%s\n", idx, pool.Hash, strings.Join(pool.Nodes, ","), outlierMarker(pool))
+			for _, field := range report.DifferingFields {
+				fmt.Printf("  %s=%s\n", field, pool.Settings[field])
+			}
+		}
+	case ValidateOutputLog:
+		for idx, pool := range report.Pools {
+			logger.Info("kubelet configuration pool", "pool", idx, "hash", pool.Hash, "nodes", pool.Nodes, "outlier", pool.Outlier, "settings", pool.Settings)
+		}
+	default:
+		// JSON embeds the report in the main output, nothing to do for the others
+	}
+}
+
+func outlierMarker(pool validator.NodePool) string {
+	if !pool.Outlier {
+		return ""
+	}
+	return " (outlier)"
+}
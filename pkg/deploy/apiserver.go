@@ -0,0 +1,89 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"fmt"
+	"sort"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+const (
+	// the API server endpoints are published in the EndpointSlices of the default/kubernetes Service
+	apiServerNamespace   = "default"
+	apiServerServiceName = "kubernetes"
+)
+
+// APIServerEndpoints returns the sorted addresses and ports of the API server endpoints found in the EndpointSlices.
+func APIServerEndpoints(slices []discoveryv1.EndpointSlice) ([]string, []int32) {
+	addrSet := make(map[string]struct{})
+	portSet := make(map[int32]struct{})
+	for _, eps := range slices {
+		for _, ep := range eps.Endpoints {
+			for _, addr := range ep.Addresses {
+				addrSet[addr] = struct{}{}
+			}
+		}
+		for _, port := range eps.Ports {
+			if port.Port != nil {
+				portSet[*port.Port] = struct{}{}
+			}
+		}
+	}
+
+	addresses := make([]string, 0, len(addrSet))
+	for addr := range addrSet {
+		addresses = append(addresses, addr)
+	}
+	sort.Strings(addresses)
+	ports := make([]int32, 0, len(portSet))
+	for port := range portSet {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	return addresses, ports
+}
+
+// DiscoverAPIServer sets in the options the API server endpoints the network policies allow the egress traffic to,
+// reading them from the cluster unless they are given.
+func DiscoverAPIServer(env *deployer.Environment, commonOpts *options.Options) error {
+	npOpts := &commonOpts.NetworkPolicies
+	if npOpts.Disabled || len(npOpts.APIServerAddresses) > 0 || len(npOpts.APIServerPorts) > 0 {
+		return nil
+	}
+	sliceList := discoveryv1.EndpointSliceList{}
+	err := env.Cli.List(env.Ctx, &sliceList, client.InNamespace(apiServerNamespace), client.MatchingLabels{
+		discoveryv1.LabelServiceName: apiServerServiceName,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot read the API server endpoints: %w", err)
+	}
+	addresses, ports := APIServerEndpoints(sliceList.Items)
+	if len(addresses) == 0 || len(ports) == 0 {
+		env.Log.Info("cannot discover the API server endpoints, allowing the default port", "addresses", addresses, "ports", ports)
+		return nil
+	}
+	env.Log.V(3).Info("API server endpoints discovered", "addresses", addresses, "ports", ports)
+	npOpts.APIServerAddresses = addresses
+	npOpts.APIServerPorts = ports
+	return nil
+}
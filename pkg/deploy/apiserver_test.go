@@ -0,0 +1,127 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+func TestAPIServerEndpoints(t *testing.T) {
+	port443 := int32(443)
+	port6443 := int32(6443)
+	slices := []discoveryv1.EndpointSlice{
+		{
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.2"}},
+				{Addresses: []string{"10.0.0.1"}},
+			},
+			Ports: []discoveryv1.EndpointPort{
+				{Port: &port6443},
+			},
+		},
+		{
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}},
+			},
+			Ports: []discoveryv1.EndpointPort{
+				{Port: &port443},
+				{Port: nil},
+			},
+		},
+	}
+
+	addresses, ports := APIServerEndpoints(slices)
+	if !reflect.DeepEqual(addresses, []string{"10.0.0.1", "10.0.0.2"}) {
+		t.Errorf("unexpected addresses: %v", addresses)
+	}
+	if !reflect.DeepEqual(ports, []int32{443, 6443}) {
+		t.Errorf("unexpected ports: %v", ports)
+	}
+}
+
+func TestDiscoverAPIServer(t *testing.T) {
+	port := int32(443)
+	eps := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: apiServerNamespace,
+			Name:      apiServerServiceName,
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: apiServerServiceName,
+			},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"192.168.1.10"}},
+		},
+		Ports: []discoveryv1.EndpointPort{
+			{Port: &port},
+		},
+	}
+
+	type testCase struct {
+		name              string
+		npOpts            options.NetworkPolicies
+		expectedAddresses []string
+		expectedPorts     []int32
+	}
+
+	testCases := []testCase{
+		{
+			name:              "discovered",
+			expectedAddresses: []string{"192.168.1.10"},
+			expectedPorts:     []int32{443},
+		},
+		{
+			name:          "given ports",
+			npOpts:        options.NetworkPolicies{APIServerPorts: []int32{8443}},
+			expectedPorts: []int32{8443},
+		},
+		{
+			name:   "disabled",
+			npOpts: options.NetworkPolicies{Disabled: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := &deployer.Environment{
+				Ctx: context.TODO(),
+				Cli: fake.NewClientBuilder().WithObjects(eps).Build(),
+				Log: testr.New(t),
+			}
+			commonOpts := &options.Options{NetworkPolicies: tc.npOpts}
+			if err := DiscoverAPIServer(env, commonOpts); err != nil {
+				t.Fatalf("DiscoverAPIServer() failed: %v", err)
+			}
+			got := commonOpts.NetworkPolicies
+			if !reflect.DeepEqual(got.APIServerAddresses, tc.expectedAddresses) || !reflect.DeepEqual(got.APIServerPorts, tc.expectedPorts) {
+				t.Errorf("got addresses=%v ports=%v expected addresses=%v ports=%v", got.APIServerAddresses, got.APIServerPorts, tc.expectedAddresses, tc.expectedPorts)
+			}
+		})
+	}
+}
@@ -19,12 +19,15 @@ package deploy
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/api"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform/detect"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
@@ -45,37 +48,145 @@ func OnCluster(env *deployer.Environment, commonOpts *options.Options) error {
 	}
 
 	env.Log.V(3).Info("detection", "platform", commonOpts.ClusterPlatform, "reason", reason, "version", commonOpts.ClusterVersion, "source", source)
-	if err := api.Deploy(env, options.API{
-		Platform: commonOpts.ClusterPlatform,
-	}); err != nil {
+	flavorDetect, flavorSource, _ := detect.FindFlavor(env.Ctx, commonOpts.ClusterPlatform, commonOpts.UserFlavor)
+	commonOpts.ClusterFlavor = flavorDetect.Discovered
+	env.Log.V(3).Info("detection", "flavor", commonOpts.ClusterFlavor, "source", flavorSource)
+
+	components := []string{ComponentAPI, ComponentUpdater, ComponentScheduler}
+	plan, err := PlanConflicts(env, commonOpts, components...)
+	if err != nil {
+		return err
+	}
+	if err := SelectImages(env.Log, commonOpts); err != nil {
+		return err
+	}
+	if err := DiscoverAPIServer(env, commonOpts); err != nil {
 		return err
 	}
-	if err := updaters.Deploy(env, commonOpts.UpdaterType, options.Updater{
-		Platform:            commonOpts.ClusterPlatform,
-		PlatformVersion:     commonOpts.ClusterVersion,
-		WaitCompletion:      commonOpts.WaitCompletion,
-		RTEConfigData:       commonOpts.RTEConfigData,
-		DaemonSet:           options.ForDaemonSet(commonOpts),
-		EnableCRIHooks:      commonOpts.UpdaterCRIHooksEnable,
-		CustomSELinuxPolicy: commonOpts.UpdaterCustomSELinuxPolicy,
-	}); err != nil {
+	if err := CheckPodSecurity(env, commonOpts, plan, components...); err != nil {
 		return err
 	}
-	if err := sched.Deploy(env, options.Scheduler{
-		Platform:               commonOpts.ClusterPlatform,
-		WaitCompletion:         commonOpts.WaitCompletion,
-		Replicas:               int32(commonOpts.Replicas),
-		PullIfNotPresent:       commonOpts.PullIfNotPresent,
-		ProfileName:            commonOpts.SchedProfileName,
-		CacheResyncPeriod:      commonOpts.SchedResyncPeriod,
-		CtrlPlaneAffinity:      commonOpts.SchedCtrlPlaneAffinity,
-		Verbose:                commonOpts.SchedVerbose,
-		ScoringStratConfigData: commonOpts.SchedScoringStratConfigData,
-		CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
-		LeaderElection:         commonOpts.Replicas > 1,
-		LeaderElectionResource: commonOpts.SchedLeaderElectResource,
-	}); err != nil {
+	if err := LoadImagePullSecret(env, commonOpts); err != nil {
 		return err
 	}
+
+	if !plan.ShouldSkip(ComponentAPI) {
+		if err := api.Deploy(env, options.API{
+			Platform: commonOpts.ClusterPlatform,
+			Patches:  commonOpts.Patches[manifests.ComponentAPI],
+			Adopt:    plan.ShouldAdopt(ComponentAPI),
+		}); err != nil {
+			return err
+		}
+	}
+	if !plan.ShouldSkip(ComponentUpdater) {
+		if err := updaters.Deploy(env, commonOpts.UpdaterType, options.Updater{
+			Platform:              commonOpts.ClusterPlatform,
+			PlatformVersion:       commonOpts.ClusterVersion,
+			WaitCompletion:        commonOpts.WaitCompletion,
+			RTEConfigData:         commonOpts.RTEConfigData,
+			DaemonSet:             options.ForDaemonSet(commonOpts),
+			Patches:               commonOpts.Patches[updaters.ComponentFromType(commonOpts.UpdaterType)],
+			ImageMirrors:          commonOpts.ImageMirrors,
+			ImagePullSecretSource: commonOpts.ImagePullSecretSource,
+			Metrics:               commonOpts.Metrics,
+			NetworkPolicies:       commonOpts.NetworkPolicies,
+			EnableCRIHooks:        commonOpts.UpdaterCRIHooksEnable,
+			CustomSELinuxPolicy:   commonOpts.UpdaterCustomSELinuxPolicy,
+			NodePoolNamespace:     commonOpts.NodePoolNamespace,
+			Adopt:                 plan.ShouldAdopt(ComponentUpdater),
+		}); err != nil {
+			return err
+		}
+	}
+	if !plan.ShouldSkip(ComponentScheduler) {
+		if err := sched.Deploy(env, options.Scheduler{
+			Platform:               commonOpts.ClusterPlatform,
+			Flavor:                 commonOpts.ClusterFlavor,
+			WaitCompletion:         commonOpts.WaitCompletion,
+			Replicas:               int32(commonOpts.Replicas),
+			PullIfNotPresent:       commonOpts.PullIfNotPresent,
+			ProfileName:            commonOpts.SchedProfileName,
+			CacheResyncPeriod:      commonOpts.SchedResyncPeriod,
+			CtrlPlaneAffinity:      commonOpts.SchedCtrlPlaneAffinity,
+			Verbose:                commonOpts.SchedVerbose,
+			ScoringStratConfigData: commonOpts.SchedScoringStratConfigData,
+			CacheParamsConfigData:  commonOpts.SchedCacheParamsConfigData,
+			LeaderElection:         commonOpts.Replicas > 1,
+			LeaderElectionResource: commonOpts.SchedLeaderElectResource,
+			LeaseDuration:          commonOpts.SchedLeaseDuration,
+			RenewDeadline:          commonOpts.SchedRenewDeadline,
+			RetryPeriod:            commonOpts.SchedRetryPeriod,
+			HighAvailability:       commonOpts.SchedHighAvailability,
+			PriorityClassName:      commonOpts.SchedPriorityClassName,
+			SchedulerResources:     commonOpts.SchedResources,
+			ControllerResources:    commonOpts.SchedControllerResources,
+			Patches:                commonOpts.Patches[manifests.ComponentSchedulerPlugin],
+			Images:                 commonOpts.Images,
+			Metrics:                commonOpts.Metrics,
+			NetworkPolicies:        commonOpts.NetworkPolicies,
+			ImageMirrors:           commonOpts.ImageMirrors,
+			ImagePullSecret:        commonOpts.ImagePullSecret,
+			ImagePullSecretSource:  commonOpts.ImagePullSecretSource,
+			Adopt:                  plan.ShouldAdopt(ComponentScheduler),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PlanConflicts looks for existing topology-aware-scheduling installations conflicting with the given components
+// and resolves the conflicts according to the policy set in the options.
+func PlanConflicts(env *deployer.Environment, commonOpts *options.Options, components ...string) (Plan, error) {
+	policy := DefaultConflictPolicy
+	if commonOpts.OnConflict != "" {
+		var err error
+		policy, err = ParseConflictPolicy(commonOpts.OnConflict)
+		if err != nil {
+			return Plan{}, err
+		}
+	}
+	own, err := GetOwnNamespaces()
+	if err != nil {
+		return Plan{}, err
+	}
+	allConflicts, err := FindConflicts(env.Ctx, env.Cli, own)
+	if err != nil {
+		return Plan{}, err
+	}
+	var conflicts []Conflict
+	for _, cf := range allConflicts {
+		if !contains(components, cf.Component) {
+			continue
+		}
+		env.Log.Info("existing installation found", "component", cf.Component, "object", cf.String(), "own", cf.Own)
+		conflicts = append(conflicts, cf)
+	}
+	plan, err := ResolveConflicts(conflicts, policy)
+	if err != nil {
+		return plan, err
+	}
+	for component := range plan.Skip {
+		env.Log.Info("skipping component because of existing installation", "component", component, "policy", policy)
+	}
+	for component := range plan.Adopt {
+		env.Log.Info("adopting existing objects", "component", component, "policy", policy)
+	}
+	return plan, nil
+}
+
+// LoadImagePullSecret reads the secret to clone in the component namespaces, if requested
+func LoadImagePullSecret(env *deployer.Environment, commonOpts *options.Options) error {
+	if commonOpts.ImagePullSecretFrom.Name == "" {
+		return nil
+	}
+	sec := corev1.Secret{}
+	err := env.Cli.Get(env.Ctx, commonOpts.ImagePullSecretFrom, &sec)
+	if err != nil {
+		return fmt.Errorf("cannot read the image pull secret source %q: %w", commonOpts.ImagePullSecretFrom.String(), err)
+	}
+	env.Log.V(3).Info("image pull secret source loaded", "source", commonOpts.ImagePullSecretFrom.String(), "name", commonOpts.ImagePullSecret)
+	commonOpts.ImagePullSecretSource = &sec
 	return nil
 }
@@ -0,0 +1,374 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+type ConflictPolicy string
+
+const (
+	// ConflictFail stops the deployment before creating anything if any conflict is found
+	ConflictFail = ConflictPolicy("fail")
+	// ConflictAdopt updates in place the objects previously created by the deployer, and reuses
+	// the installations it does not own, skipping the corresponding component
+	ConflictAdopt = ConflictPolicy("adopt")
+	// ConflictSkipComponent skips the deployment of any component with conflicts
+	ConflictSkipComponent = ConflictPolicy("skip-component")
+)
+
+const (
+	DefaultConflictPolicy = ConflictFail
+)
+
+func ParseConflictPolicy(policy string) (ConflictPolicy, error) {
+	switch ConflictPolicy(policy) {
+	case ConflictFail, ConflictAdopt, ConflictSkipComponent:
+		return ConflictPolicy(policy), nil
+	default:
+		return "", fmt.Errorf("unsupported conflict policy %q", policy)
+	}
+}
+
+const (
+	ComponentAPI       = "api"
+	ComponentUpdater   = "updater"
+	ComponentScheduler = "scheduler"
+)
+
+const (
+	nrtGroup    = "topology.node.k8s.io"
+	nrtResource = "noderesourcetopologies"
+	nrtCRDName  = nrtResource + "." + nrtGroup
+
+	labelManagedBy = "app.kubernetes.io/managed-by"
+	labelOLMOwner  = "olm.owner"
+)
+
+// Conflict is an object already existing on the cluster which conflicts with a component to deploy.
+type Conflict struct {
+	Component string
+	Kind      string
+	Namespace string
+	Name      string
+	// Owner describes who manages the object, if known
+	Owner   string
+	Details string
+	// Own is true if the object is expected to be created by the deployer itself
+	Own bool
+}
+
+func (cf Conflict) String() string {
+	name := cf.Name
+	if cf.Namespace != "" {
+		name = cf.Namespace + "/" + cf.Name
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %s %s", cf.Component, cf.Kind, name)
+	if cf.Owner != "" {
+		fmt.Fprintf(&sb, " owner=%s", cf.Owner)
+	}
+	if cf.Details != "" {
+		fmt.Fprintf(&sb, " (%s)", cf.Details)
+	}
+	return sb.String()
+}
+
+// Plan tells how to deploy each component given the conflicts found.
+type Plan struct {
+	Skip  map[string]bool
+	Adopt map[string]bool
+}
+
+func (pl Plan) ShouldSkip(component string) bool {
+	return pl.Skip[component]
+}
+
+func (pl Plan) ShouldAdopt(component string) bool {
+	return pl.Adopt[component]
+}
+
+// ResolveConflicts computes the deployment plan according to the policy. Returns error if the policy
+// is ConflictFail and any conflict is found.
+func ResolveConflicts(conflicts []Conflict, policy ConflictPolicy) (Plan, error) {
+	plan := Plan{
+		Skip:  make(map[string]bool),
+		Adopt: make(map[string]bool),
+	}
+	if len(conflicts) == 0 {
+		return plan, nil
+	}
+	if policy == ConflictFail {
+		desc := make([]string, 0, len(conflicts))
+		for _, cf := range conflicts {
+			desc = append(desc, cf.String())
+		}
+		return plan, fmt.Errorf("existing topology-aware-scheduling installation found: %s", strings.Join(desc, "; "))
+	}
+
+	foreign := make(map[string]bool)
+	for _, cf := range conflicts {
+		plan.Adopt[cf.Component] = true
+		if !cf.Own {
+			foreign[cf.Component] = true
+		}
+	}
+	for component := range plan.Adopt {
+		if policy == ConflictSkipComponent || foreign[component] {
+			plan.Skip[component] = true
+			delete(plan.Adopt, component)
+		}
+	}
+	return plan, nil
+}
+
+// OwnNamespaces are the namespaces the deployer creates its namespaced objects in. Objects found there
+// are considered owned by the deployer, unless managed by someone else.
+type OwnNamespaces struct {
+	Updater   []string
+	Scheduler []string
+}
+
+func GetOwnNamespaces() (OwnNamespaces, error) {
+	ret := OwnNamespaces{}
+	for _, component := range []string{manifests.ComponentResourceTopologyExporter, manifests.ComponentNodeFeatureDiscovery} {
+		ns, err := manifests.Namespace(component)
+		if err != nil {
+			return ret, err
+		}
+		ret.Updater = append(ret.Updater, ns.Name)
+	}
+	ns, err := manifests.Namespace(manifests.ComponentSchedulerPlugin)
+	if err != nil {
+		return ret, err
+	}
+	ret.Scheduler = append(ret.Scheduler, ns.Name)
+	return ret, nil
+}
+
+// FindConflicts looks for existing NRT CRDs, for other DaemonSets allowed to write NRT objects, and for other
+// schedulers running the NodeResourceTopologyMatch plugin.
+func FindConflicts(ctx context.Context, cli client.Client, own OwnNamespaces) ([]Conflict, error) {
+	var conflicts []Conflict
+
+	crd := apiextensionsv1.CustomResourceDefinition{}
+	err := cli.Get(ctx, client.ObjectKey{Name: nrtCRDName}, &crd)
+	if err == nil {
+		conflicts = append(conflicts, CRDConflicts(crd)...)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	roles := rbacv1.ClusterRoleList{}
+	if err := cli.List(ctx, &roles); err != nil {
+		return nil, err
+	}
+	bindings := rbacv1.ClusterRoleBindingList{}
+	if err := cli.List(ctx, &bindings); err != nil {
+		return nil, err
+	}
+	dss := appsv1.DaemonSetList{}
+	if err := cli.List(ctx, &dss); err != nil {
+		return nil, err
+	}
+	conflicts = append(conflicts, NRTWriterConflicts(roles.Items, bindings.Items, dss.Items, own.Updater)...)
+
+	cms := corev1.ConfigMapList{}
+	if err := cli.List(ctx, &cms); err != nil {
+		return nil, err
+	}
+	deps := appsv1.DeploymentList{}
+	if err := cli.List(ctx, &deps); err != nil {
+		return nil, err
+	}
+	conflicts = append(conflicts, SchedulerConflicts(cms.Items, deps.Items, own.Scheduler)...)
+	return conflicts, nil
+}
+
+// CRDConflicts reports the existing NRT CRD. The CRD is considered owned by the deployer unless
+// it is managed by someone else, like an operator.
+func CRDConflicts(crd apiextensionsv1.CustomResourceDefinition) []Conflict {
+	var versions []string
+	for _, ver := range crd.Spec.Versions {
+		if ver.Served {
+			versions = append(versions, ver.Name)
+		}
+	}
+	owner := objectOwner(crd.Labels, crd.OwnerReferences)
+	return []Conflict{
+		{
+			Component: ComponentAPI,
+			Kind:      "CustomResourceDefinition",
+			Name:      crd.Name,
+			Owner:     owner,
+			Details:   "served versions: " + strings.Join(versions, ","),
+			Own:       owner == "",
+		},
+	}
+}
+
+// NRTWriterConflicts reports the DaemonSets whose service account is allowed to write NRT objects.
+func NRTWriterConflicts(roles []rbacv1.ClusterRole, bindings []rbacv1.ClusterRoleBinding, dss []appsv1.DaemonSet, ownNamespaces []string) []Conflict {
+	writerRoles := make(map[string]bool)
+	for _, role := range roles {
+		if canWriteNRT(role.Rules) {
+			writerRoles[role.Name] = true
+		}
+	}
+	writerSAs := make(map[string]bool)
+	for _, binding := range bindings {
+		if binding.RoleRef.Kind != "ClusterRole" || !writerRoles[binding.RoleRef.Name] {
+			continue
+		}
+		for _, subject := range binding.Subjects {
+			if subject.Kind == rbacv1.ServiceAccountKind {
+				writerSAs[subject.Namespace+"/"+subject.Name] = true
+			}
+		}
+	}
+
+	var conflicts []Conflict
+	for _, ds := range dss {
+		sa := ds.Spec.Template.Spec.ServiceAccountName
+		if sa == "" {
+			sa = "default"
+		}
+		if !writerSAs[ds.Namespace+"/"+sa] {
+			continue
+		}
+		owner := objectOwner(ds.Labels, ds.OwnerReferences)
+		conflicts = append(conflicts, Conflict{
+			Component: ComponentUpdater,
+			Kind:      "DaemonSet",
+			Namespace: ds.Namespace,
+			Name:      ds.Name,
+			Owner:     owner,
+			Details:   "service account " + sa + " can write NodeResourceTopology objects",
+			Own:       owner == "" && contains(ownNamespaces, ds.Namespace),
+		})
+	}
+	return conflicts
+}
+
+// SchedulerConflicts reports the scheduler configurations enabling the NodeResourceTopologyMatch plugin,
+// along with the Deployments using them.
+func SchedulerConflicts(cms []corev1.ConfigMap, deps []appsv1.Deployment, ownNamespaces []string) []Conflict {
+	var conflicts []Conflict
+	for _, cm := range cms {
+		var profiles []string
+		found := false
+		for _, data := range cm.Data {
+			if !strings.Contains(data, manifests.SchedulerPluginName) {
+				continue
+			}
+			found = true
+			params, _ := manifests.DecodeSchedulerProfilesFromData([]byte(data))
+			for _, param := range params {
+				profiles = append(profiles, param.ProfileName)
+			}
+		}
+		if !found {
+			continue
+		}
+		sort.Strings(profiles)
+
+		details := "enables " + manifests.SchedulerPluginName
+		if len(profiles) > 0 {
+			details += " in profiles " + strings.Join(profiles, ",")
+		}
+		kind, name, owner := "ConfigMap", cm.Name, objectOwner(cm.Labels, cm.OwnerReferences)
+		if dp := findDeploymentMountingConfigMap(deps, cm); dp != nil {
+			kind, name, owner = "Deployment", dp.Name, objectOwner(dp.Labels, dp.OwnerReferences)
+			details += " from configmap " + cm.Name
+		}
+		conflicts = append(conflicts, Conflict{
+			Component: ComponentScheduler,
+			Kind:      kind,
+			Namespace: cm.Namespace,
+			Name:      name,
+			Owner:     owner,
+			Details:   details,
+			Own:       owner == "" && contains(ownNamespaces, cm.Namespace),
+		})
+	}
+	return conflicts
+}
+
+func findDeploymentMountingConfigMap(deps []appsv1.Deployment, cm corev1.ConfigMap) *appsv1.Deployment {
+	for idx := range deps {
+		dp := &deps[idx]
+		if dp.Namespace != cm.Namespace {
+			continue
+		}
+		for _, vol := range dp.Spec.Template.Spec.Volumes {
+			if vol.ConfigMap != nil && vol.ConfigMap.Name == cm.Name {
+				return dp
+			}
+		}
+	}
+	return nil
+}
+
+// canWriteNRT tells if the rules explicitly grant write access to the NRT objects. Wildcard groups and resources,
+// like the cluster-admin ones, are granted to many agents which are not topology updaters, so they don't count.
+func canWriteNRT(rules []rbacv1.PolicyRule) bool {
+	for _, rule := range rules {
+		if !contains(rule.APIGroups, nrtGroup) || !contains(rule.Resources, nrtResource) {
+			continue
+		}
+		for _, verb := range []string{"create", "update", "patch", rbacv1.VerbAll} {
+			if contains(rule.Verbs, verb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func objectOwner(labels map[string]string, refs []metav1.OwnerReference) string {
+	if len(refs) > 0 {
+		return refs[0].Kind + "/" + refs[0].Name
+	}
+	if owner, ok := labels[labelOLMOwner]; ok {
+		return owner
+	}
+	return labels[labelManagedBy]
+}
+
+func contains(items []string, item string) bool {
+	for _, it := range items {
+		if it == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,272 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolveConflicts(t *testing.T) {
+	ownCRD := Conflict{Component: ComponentAPI, Kind: "CustomResourceDefinition", Name: nrtCRDName, Own: true}
+	foreignUpdater := Conflict{Component: ComponentUpdater, Kind: "DaemonSet", Namespace: "openshift-numaresources", Name: "rte", Owner: "NUMAResourcesOperator/numaresourcesoperator"}
+
+	type testCase struct {
+		name          string
+		conflicts     []Conflict
+		policy        ConflictPolicy
+		expectedError bool
+		expectedSkip  []string
+		expectedAdopt []string
+	}
+
+	testCases := []testCase{
+		{
+			name:   "no conflicts",
+			policy: ConflictFail,
+		},
+		{
+			name:          "fail",
+			conflicts:     []Conflict{ownCRD},
+			policy:        ConflictFail,
+			expectedError: true,
+		},
+		{
+			name:          "adopt",
+			conflicts:     []Conflict{ownCRD, foreignUpdater},
+			policy:        ConflictAdopt,
+			expectedSkip:  []string{ComponentUpdater},
+			expectedAdopt: []string{ComponentAPI},
+		},
+		{
+			name:         "skip component",
+			conflicts:    []Conflict{ownCRD, foreignUpdater},
+			policy:       ConflictSkipComponent,
+			expectedSkip: []string{ComponentAPI, ComponentUpdater},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := ResolveConflicts(tc.conflicts, tc.policy)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, component := range []string{ComponentAPI, ComponentUpdater, ComponentScheduler} {
+				if plan.ShouldSkip(component) != contains(tc.expectedSkip, component) {
+					t.Errorf("component %q: skip=%v expected %v", component, plan.ShouldSkip(component), tc.expectedSkip)
+				}
+				if plan.ShouldAdopt(component) != contains(tc.expectedAdopt, component) {
+					t.Errorf("component %q: adopt=%v expected %v", component, plan.ShouldAdopt(component), tc.expectedAdopt)
+				}
+			}
+		})
+	}
+}
+
+func TestCRDConflicts(t *testing.T) {
+	crd := apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nrtCRDName,
+			Labels: map[string]string{
+				labelOLMOwner: "numaresources-operator.v4.18.0",
+			},
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: false},
+				{Name: "v1alpha2", Served: true},
+			},
+		},
+	}
+	got := CRDConflicts(crd)
+	expected := []Conflict{
+		{
+			Component: ComponentAPI,
+			Kind:      "CustomResourceDefinition",
+			Name:      nrtCRDName,
+			Owner:     "numaresources-operator.v4.18.0",
+			Details:   "served versions: v1alpha2",
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("mismatch:\ngot=%#v\nexpected=%#v", got, expected)
+	}
+}
+
+func TestNRTWriterConflicts(t *testing.T) {
+	roles := []rbacv1.ClusterRole{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rte"},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{nrtGroup},
+					Resources: []string{nrtResource},
+					Verbs:     []string{"create", "get", "update"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "nrt-reader"},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{nrtGroup},
+					Resources: []string{nrtResource},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{rbacv1.APIGroupAll},
+					Resources: []string{rbacv1.ResourceAll},
+					Verbs:     []string{rbacv1.VerbAll},
+				},
+			},
+		},
+	}
+	bindings := []rbacv1.ClusterRoleBinding{
+		makeClusterRoleBinding("rte", "tas-topology-updater", "rte"),
+		makeClusterRoleBinding("rte", "node-feature-discovery", "nfd-topology-updater"),
+		makeClusterRoleBinding("nrt-reader", "monitoring", "exporter"),
+		makeClusterRoleBinding("cluster-admin", "kube-system", "cni-agent"),
+	}
+	dss := []appsv1.DaemonSet{
+		makeDaemonSet("tas-topology-updater", "readwrite", "rte", nil),
+		makeDaemonSet("node-feature-discovery", "nfd-topology-updater", "nfd-topology-updater", map[string]string{labelManagedBy: "nfd-operator"}),
+		makeDaemonSet("monitoring", "exporter", "exporter", nil),
+		makeDaemonSet("kube-system", "cni-agent", "cni-agent", nil),
+	}
+
+	got := NRTWriterConflicts(roles, bindings, dss, []string{"tas-topology-updater", "node-feature-discovery"})
+	if len(got) != 2 {
+		t.Fatalf("unexpected conflicts: %#v", got)
+	}
+	if got[0].Name != "readwrite" || !got[0].Own {
+		t.Errorf("own updater not detected: %#v", got[0])
+	}
+	if got[1].Name != "nfd-topology-updater" || got[1].Own || got[1].Owner != "nfd-operator" {
+		t.Errorf("operator managed updater not detected: %#v", got[1])
+	}
+}
+
+func TestSchedulerConflicts(t *testing.T) {
+	cms := []corev1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "numa-scheduler", Name: "scheduler-config"},
+			Data: map[string]string{
+				"config.yaml": `apiVersion: kubescheduler.config.k8s.io/v1
+kind: KubeSchedulerConfiguration
+profiles:
+- schedulerName: numa-scheduler
+  plugins:
+    filter:
+      enabled:
+      - name: NodeResourceTopologyMatch
+  pluginConfig:
+  - name: NodeResourceTopologyMatch
+    args:
+      cacheResyncPeriodSeconds: 5
+`,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "coredns"},
+			Data: map[string]string{
+				"Corefile": ".:53 {}",
+			},
+		},
+	}
+	deps := []appsv1.Deployment{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "numa-scheduler", Name: "secondary-scheduler"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{
+							{
+								Name: "config",
+								VolumeSource: corev1.VolumeSource{
+									ConfigMap: &corev1.ConfigMapVolumeSource{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "scheduler-config"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := SchedulerConflicts(cms, deps, []string{"tas-scheduler"})
+	if len(got) != 1 {
+		t.Fatalf("unexpected conflicts: %#v", got)
+	}
+	if got[0].Kind != "Deployment" || got[0].Name != "secondary-scheduler" || got[0].Own {
+		t.Errorf("unexpected conflict: %#v", got[0])
+	}
+}
+
+func makeClusterRoleBinding(role, namespace, serviceAccount string) rbacv1.ClusterRoleBinding {
+	return rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: role + "-" + serviceAccount},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     role,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: namespace,
+				Name:      serviceAccount,
+			},
+		},
+	}
+}
+
+func makeDaemonSet(namespace, name, serviceAccount string, labels map[string]string) appsv1.DaemonSet {
+	return appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					ServiceAccountName: serviceAccount,
+				},
+			},
+		},
+	}
+}
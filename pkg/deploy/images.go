@@ -0,0 +1,83 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+type IncompatibleImagesPolicy string
+
+const (
+	// IncompatibleImagesWarn uses the default images if no bundled image set supports the cluster version
+	IncompatibleImagesWarn = IncompatibleImagesPolicy("warn")
+	// IncompatibleImagesFail stops the deployment if no bundled image set supports the cluster version
+	IncompatibleImagesFail = IncompatibleImagesPolicy("fail")
+)
+
+const (
+	DefaultIncompatibleImagesPolicy = IncompatibleImagesWarn
+)
+
+func ParseIncompatibleImagesPolicy(policy string) (IncompatibleImagesPolicy, error) {
+	switch IncompatibleImagesPolicy(policy) {
+	case IncompatibleImagesWarn, IncompatibleImagesFail:
+		return IncompatibleImagesPolicy(policy), nil
+	default:
+		return "", fmt.Errorf("unsupported incompatible images policy %q", policy)
+	}
+}
+
+// SelectImages sets in the options the images of the newest bundled image set supporting the cluster version.
+// The TAS_* environment variables still override the selected images.
+func SelectImages(logger logr.Logger, commonOpts *options.Options) error {
+	policy := DefaultIncompatibleImagesPolicy
+	if commonOpts.OnIncompatibleImages != "" {
+		var err error
+		policy, err = ParseIncompatibleImagesPolicy(commonOpts.OnIncompatibleImages)
+		if err != nil {
+			return err
+		}
+	}
+
+	kubeVer, err := platform.KubernetesVersion(commonOpts.ClusterPlatform, commonOpts.ClusterVersion)
+	if err != nil {
+		logger.Info("cannot infer the kubernetes version", "platform", commonOpts.ClusterPlatform, "version", commonOpts.ClusterVersion, "error", err)
+	}
+	imgs, is, ok, err := images.GetForKubernetesVersion(kubeVer.String())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		supported := strings.Join(images.SupportedKubernetesVersions(), ", ")
+		if policy == IncompatibleImagesFail {
+			return fmt.Errorf("no bundled image set supports kubernetes version %q (supported: %s)", kubeVer, supported)
+		}
+		logger.Info("no bundled image set supports the cluster version, using the default images", "kubernetesVersion", kubeVer, "supported", supported)
+	} else {
+		logger.Info("image set selected", "name", is.Name, "kubernetesVersion", kubeVer)
+	}
+	commonOpts.Images = imgs
+	return nil
+}
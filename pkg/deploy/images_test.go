@@ -0,0 +1,99 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+func TestSelectImages(t *testing.T) {
+	type testCase struct {
+		name          string
+		plat          platform.Platform
+		ver           platform.Version
+		policy        string
+		expectedImage string
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name:          "newest set",
+			plat:          platform.Kubernetes,
+			ver:           "v1.28.2",
+			expectedImage: images.SchedulerPluginSchedulerDefaultImageTag,
+		},
+		{
+			name:          "older set",
+			plat:          platform.Kubernetes,
+			ver:           "v1.26.9",
+			expectedImage: "registry.k8s.io/scheduler-plugins/kube-scheduler:v0.26.7",
+		},
+		{
+			name:          "openshift",
+			plat:          platform.OpenShift,
+			ver:           "4.13",
+			expectedImage: "registry.k8s.io/scheduler-plugins/kube-scheduler:v0.26.7",
+		},
+		{
+			name:          "unsupported version warns",
+			plat:          platform.Kubernetes,
+			ver:           "v1.31.0",
+			expectedImage: images.SchedulerPluginSchedulerDefaultImageTag,
+		},
+		{
+			name:          "unsupported version fails",
+			plat:          platform.Kubernetes,
+			ver:           "v1.31.0",
+			policy:        string(IncompatibleImagesFail),
+			expectedError: true,
+		},
+		{
+			name:          "unknown policy",
+			plat:          platform.Kubernetes,
+			ver:           "v1.28.2",
+			policy:        "foo",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			commonOpts := options.Options{
+				ClusterPlatform:      tc.plat,
+				ClusterVersion:       tc.ver,
+				OnIncompatibleImages: tc.policy,
+			}
+			err := SelectImages(testr.New(t), &commonOpts)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("got error %v, expected error %v", err, tc.expectedError)
+			}
+			if tc.expectedError {
+				return
+			}
+			if got := commonOpts.Images.SchedulerPluginScheduler; got != tc.expectedImage {
+				t.Errorf("scheduler image got=%q expected=%q", got, tc.expectedImage)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	schedmanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/sched"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+const (
+	// the namespace probed to detect the pod security level enforced by default
+	podSecurityProbeNamespace = "default"
+	podSecurityProbeName      = "tas-pod-security-probe"
+)
+
+// PodSecurityRequirement is the pod security level the pods of a component need in their namespace.
+type PodSecurityRequirement struct {
+	Component string
+	Namespace string
+	Level     string
+}
+
+func (req PodSecurityRequirement) String() string {
+	return fmt.Sprintf("namespace %q needs pod security level %q for the %s pods", req.Namespace, req.Level, req.Component)
+}
+
+// PodSecurityRequirements returns the pod security requirements of the components. The API component has no pods.
+func PodSecurityRequirements(commonOpts *options.Options, components ...string) ([]PodSecurityRequirement, error) {
+	var reqs []PodSecurityRequirement
+	for _, component := range components {
+		var mfComponent string
+		switch component {
+		case ComponentUpdater:
+			mfComponent = updaters.ComponentFromType(commonOpts.UpdaterType)
+		case ComponentScheduler:
+			mfComponent = manifests.ComponentSchedulerPlugin
+		default:
+			continue
+		}
+		ns, err := manifests.Namespace(mfComponent)
+		if err != nil {
+			return nil, err
+		}
+		name := ns.Name
+		if component == ComponentScheduler && (commonOpts.ClusterPlatform == platform.OpenShift || commonOpts.ClusterPlatform == platform.HyperShift) {
+			name = schedmanifests.NamespaceOpenShift
+		}
+		reqs = append(reqs, PodSecurityRequirement{
+			Component: component,
+			Namespace: name,
+			Level:     manifests.PodSecurityLevel(mfComponent),
+		})
+	}
+	return reqs, nil
+}
+
+// CheckPodSecurity reports which namespace needs which pod security level if the cluster enforces pod security,
+// and fails if an existing namespace would reject the pods of a component not adopting it.
+func CheckPodSecurity(env *deployer.Environment, commonOpts *options.Options, plan Plan, components ...string) error {
+	reqs, err := PodSecurityRequirements(commonOpts, components...)
+	if err != nil {
+		return err
+	}
+
+	defaultLevel, err := probeDefaultPodSecurity(env, commonOpts.Images.OrGet().Pause)
+	if err != nil {
+		env.Log.Info("cannot detect the default pod security level", "error", err)
+	} else if defaultLevel != "" && defaultLevel != manifests.PodSecurityPrivileged {
+		env.Log.Info("the cluster enforces pod security by default", "level", defaultLevel)
+	}
+
+	var rejections []string
+	for _, req := range reqs {
+		if plan.ShouldSkip(req.Component) {
+			continue
+		}
+		ns := corev1.Namespace{}
+		err := env.Cli.Get(env.Ctx, client.ObjectKey{Name: req.Namespace}, &ns)
+		if apierrors.IsNotFound(err) {
+			if defaultLevel != "" && !manifests.PodSecurityAllows(defaultLevel, req.Level) {
+				env.Log.Info("the namespace is labeled to admit the component pods", "namespace", req.Namespace, "component", req.Component, "level", req.Level)
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		enforced, ok := ns.Labels[manifests.PodSecurityLabelEnforce]
+		if !ok {
+			enforced = defaultLevel
+		}
+		if enforced == "" || manifests.PodSecurityAllows(enforced, req.Level) {
+			continue
+		}
+		if plan.ShouldAdopt(req.Component) {
+			env.Log.Info("relabeling the existing namespace to admit the component pods", "namespace", req.Namespace, "component", req.Component, "enforced", enforced, "level", req.Level)
+			continue
+		}
+		rejections = append(rejections, fmt.Sprintf("%s, but enforces %q", req.String(), enforced))
+	}
+	if len(rejections) > 0 {
+		return fmt.Errorf("pod security admission would reject the component pods: %s", strings.Join(rejections, "; "))
+	}
+	return nil
+}
+
+// probeDefaultPodSecurity returns the pod security level enforced on the namespaces without pod security labels,
+// creating in dry-run mode pods needing decreasing levels. Returns empty if the probe namespace is labeled.
+func probeDefaultPodSecurity(env *deployer.Environment, image string) (string, error) {
+	ns := corev1.Namespace{}
+	if err := env.Cli.Get(env.Ctx, client.ObjectKey{Name: podSecurityProbeNamespace}, &ns); err != nil {
+		return "", err
+	}
+	if _, ok := ns.Labels[manifests.PodSecurityLabelEnforce]; ok {
+		env.Log.V(3).Info("the probe namespace sets its own pod security level", "namespace", podSecurityProbeNamespace)
+		return "", nil
+	}
+	for _, level := range []string{manifests.PodSecurityPrivileged, manifests.PodSecurityBaseline} {
+		err := env.Cli.Create(env.Ctx, NewPodSecurityProbe(podSecurityProbeNamespace, image, level), client.DryRunAll)
+		if err == nil {
+			return level, nil
+		}
+		if !IsPodSecurityRejection(err) {
+			return "", err
+		}
+	}
+	return manifests.PodSecurityRestricted, nil
+}
+
+// NewPodSecurityProbe creates a pod which needs the given pod security level: mounting a host path needs privileged,
+// a container without the restricted security context needs baseline.
+func NewPodSecurityProbe(namespace, image, level string) *corev1.Pod {
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      podSecurityProbeName,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "probe",
+					Image: image,
+				},
+			},
+		},
+	}
+	if level == manifests.PodSecurityPrivileged {
+		pod.Spec.Volumes = []corev1.Volume{
+			{
+				Name: "host",
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{Path: "/"},
+				},
+			},
+		}
+	}
+	return pod
+}
+
+// IsPodSecurityRejection tells if the error comes from the pod security admission
+func IsPodSecurityRejection(err error) bool {
+	return apierrors.IsForbidden(err) && strings.Contains(err.Error(), "violates PodSecurity")
+}
@@ -0,0 +1,130 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/updaters"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+)
+
+func TestPodSecurityRequirements(t *testing.T) {
+	commonOpts := &options.Options{
+		ClusterPlatform: platform.Kubernetes,
+		UpdaterType:     updaters.RTE,
+	}
+	reqs, err := PodSecurityRequirements(commonOpts, ComponentAPI, ComponentUpdater, ComponentScheduler)
+	if err != nil {
+		t.Fatalf("PodSecurityRequirements() failed: %v", err)
+	}
+	if len(reqs) != 2 {
+		t.Fatalf("unexpected requirements: %v", reqs)
+	}
+	if reqs[0].Component != ComponentUpdater || reqs[0].Level != manifests.PodSecurityPrivileged {
+		t.Errorf("unexpected updater requirement: %v", reqs[0])
+	}
+	if reqs[1].Component != ComponentScheduler || reqs[1].Level != manifests.PodSecurityBaseline {
+		t.Errorf("unexpected scheduler requirement: %v", reqs[1])
+	}
+}
+
+func TestCheckPodSecurity(t *testing.T) {
+	commonOpts := &options.Options{
+		ClusterPlatform: platform.Kubernetes,
+		UpdaterType:     updaters.RTE,
+	}
+	reqs, err := PodSecurityRequirements(commonOpts, ComponentUpdater)
+	if err != nil {
+		t.Fatalf("PodSecurityRequirements() failed: %v", err)
+	}
+
+	defaultNs := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: podSecurityProbeNamespace},
+	}
+	namespaceWithLevel := func(level string) *corev1.Namespace {
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: reqs[0].Namespace},
+		}
+		if level != "" {
+			ns.Labels = map[string]string{manifests.PodSecurityLabelEnforce: level}
+		}
+		return ns
+	}
+
+	type testCase struct {
+		name          string
+		objs          []client.Object
+		plan          Plan
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "missing namespace",
+			objs: []client.Object{defaultNs},
+		},
+		{
+			name: "privileged namespace",
+			objs: []client.Object{defaultNs, namespaceWithLevel(manifests.PodSecurityPrivileged)},
+		},
+		{
+			name: "unlabeled namespace",
+			objs: []client.Object{defaultNs, namespaceWithLevel("")},
+		},
+		{
+			name:          "restricted namespace",
+			objs:          []client.Object{defaultNs, namespaceWithLevel(manifests.PodSecurityRestricted)},
+			expectedError: true,
+		},
+		{
+			name: "restricted namespace adopted",
+			objs: []client.Object{defaultNs, namespaceWithLevel(manifests.PodSecurityRestricted)},
+			plan: Plan{Adopt: map[string]bool{ComponentUpdater: true}},
+		},
+		{
+			name: "restricted namespace skipped",
+			objs: []client.Object{defaultNs, namespaceWithLevel(manifests.PodSecurityRestricted)},
+			plan: Plan{Skip: map[string]bool{ComponentUpdater: true}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := &deployer.Environment{
+				Ctx: context.TODO(),
+				Cli: fake.NewClientBuilder().WithObjects(tc.objs...).Build(),
+				Log: testr.New(t),
+			}
+			err := CheckPodSecurity(env, commonOpts, tc.plan, ComponentUpdater)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("CheckPodSecurity() error=%v expected error=%v", err, tc.expectedError)
+			}
+		})
+	}
+}
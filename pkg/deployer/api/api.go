@@ -26,27 +26,46 @@ import (
 	apimanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/api"
 	apiwait "github.com/k8stopologyawareschedwg/deployer/pkg/objectwait/api"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/patches"
 )
 
 func SetupNamespace(plat platform.Platform) (*corev1.Namespace, string, error) {
 	return nil, "", fmt.Errorf("the API is a cluster scoped resource")
 }
 
+// GetManifests renders the API manifests, then applies the user patches.
+// Render, deploy and remove all use it, so they always act on the same objects.
+func GetManifests(opts options.API) (apimanifests.Manifests, error) {
+	mf, err := apimanifests.NewWithOptions(options.Render{
+		Platform: opts.Platform,
+	})
+	if err != nil {
+		return mf, err
+	}
+	ret, err := mf.Render()
+	if err != nil {
+		return ret, err
+	}
+	if err := patches.Apply(ret.ToObjects(), opts.Patches); err != nil {
+		return ret, err
+	}
+	return ret, nil
+}
+
 func Deploy(env *deployer.Environment, opts options.API) error {
 	var err error
 	env = env.WithName("API")
 	env.Log.Info("deploying topology-aware-scheduling API")
 
-	mf, err := apimanifests.NewWithOptions(options.Render{
-		Platform: opts.Platform,
-	})
+	mf, err := GetManifests(opts)
 	if err != nil {
 		return err
 	}
 	env.Log.V(3).Info("API manifests loaded")
 
+	createObject := env.CreateObjectFunc(opts.Adopt)
 	for _, wo := range apiwait.Creatable(mf, env.Cli, env.Log) {
-		if err := env.CreateObject(wo.Obj); err != nil {
+		if err := createObject(wo.Obj); err != nil {
 			return err
 		}
 
@@ -69,9 +88,7 @@ func Remove(env *deployer.Environment, opts options.API) error {
 	env = env.WithName("API")
 	env.Log.Info("removing topology-aware-scheduling API")
 
-	mf, err := apimanifests.NewWithOptions(options.Render{
-		Platform: opts.Platform,
-	})
+	mf, err := GetManifests(opts)
 	if err != nil {
 		return err
 	}
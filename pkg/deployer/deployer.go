@@ -22,10 +22,14 @@ import (
 	"github.com/go-logr/logr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/clientutil"
 )
 
+// FieldManager is the field owner of the objects the deployer applies
+const FieldManager = "topology-aware-scheduling-deployer"
+
 type Environment struct {
 	Ctx context.Context
 	Cli client.Client
@@ -62,6 +66,32 @@ func (env Environment) CreateObject(obj client.Object) error {
 	return nil
 }
 
+// CreateOrUpdateObject server-side applies the object: it is created if missing, otherwise only the fields set by
+// the deployer are updated, keeping the labels, annotations and fields other actors set on the live object.
+func (env Environment) CreateOrUpdateObject(obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, env.Cli.Scheme())
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk) // apply requests need the full type information
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+	if err := env.Cli.Patch(env.Ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {
+		env.Log.Info("error applying", "kind", gvk.Kind, "name", obj.GetName(), "error", err)
+		return err
+	}
+	env.Log.Info("applied", "kind", gvk.Kind, "name", obj.GetName())
+	return nil
+}
+
+// CreateObjectFunc returns the function to create objects: if adopt is true, already existing objects are updated.
+func (env Environment) CreateObjectFunc(adopt bool) func(obj client.Object) error {
+	if adopt {
+		return env.CreateOrUpdateObject
+	}
+	return env.CreateObject
+}
+
 func (env Environment) DeleteObject(obj client.Object) error {
 	objKind := obj.GetObjectKind().GroupVersionKind().Kind // shortcut
 	if err := env.Cli.Delete(env.Ctx, obj); err != nil {
@@ -0,0 +1,77 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package deployer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreateOrUpdateObjectKeepsLiveFields(t *testing.T) {
+	live := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "tas",
+			Name:      "scheduler",
+			Annotations: map[string]string{
+				"openshift.io/internal-registry-pull-secret-ref": "scheduler-dockercfg",
+			},
+		},
+		ImagePullSecrets: []corev1.LocalObjectReference{
+			{Name: "scheduler-dockercfg"},
+		},
+	}
+	env := Environment{
+		Ctx: context.Background(),
+		Cli: fake.NewClientBuilder().WithObjects(live).Build(),
+		Log: testr.New(t),
+	}
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "tas",
+			Name:      "scheduler",
+			Labels: map[string]string{
+				"app": "scheduler",
+			},
+		},
+	}
+	if err := env.CreateOrUpdateObject(sa); err != nil {
+		t.Fatalf("CreateOrUpdateObject() failed: %v", err)
+	}
+
+	got := corev1.ServiceAccount{}
+	if err := env.Cli.Get(env.Ctx, client.ObjectKeyFromObject(sa), &got); err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if got.Labels["app"] != "scheduler" {
+		t.Errorf("rendered labels not applied: %v", got.Labels)
+	}
+	if got.Annotations["openshift.io/internal-registry-pull-secret-ref"] != "scheduler-dockercfg" {
+		t.Errorf("live annotations lost: %v", got.Annotations)
+	}
+	if len(got.ImagePullSecrets) != 1 {
+		t.Errorf("live image pull secrets lost: %v", got.ImagePullSecrets)
+	}
+}
@@ -51,6 +51,19 @@ func FindVersion(ctx context.Context, plat platform.Platform, userSupplied platf
 	return FindVersionFromEnv(&env, plat, userSupplied)
 }
 
+func FindFlavor(ctx context.Context, plat platform.Platform, userSupplied platform.Flavor) (FlavorInfo, string, error) {
+	env := platform.Environment{
+		Environment: deployer.Environment{
+			Ctx: ctx,
+			Log: logr.Discard(),
+		},
+	}
+	if err := env.EnsureClient(); err != nil {
+		return FlavorInfo{}, DetectedFailure, err
+	}
+	return FindFlavorFromEnv(&env, plat, userSupplied)
+}
+
 func FindPlatformFromEnv(env *platform.Environment, userSupplied platform.Platform) (PlatformInfo, string, error) {
 	do := PlatformInfo{
 		AutoDetected: platform.Unknown,
@@ -100,3 +113,28 @@ func FindVersionFromEnv(env *platform.Environment, plat platform.Platform, userS
 	do.Discovered = do.AutoDetected
 	return do, DetectedFromCluster, nil
 }
+
+func FindFlavorFromEnv(env *platform.Environment, plat platform.Platform, userSupplied platform.Flavor) (FlavorInfo, string, error) {
+	do := FlavorInfo{
+		AutoDetected: platform.FlavorGeneric,
+		UserSupplied: userSupplied,
+		Discovered:   platform.FlavorGeneric,
+	}
+	if err := env.EnsureClient(); err != nil {
+		return do, DetectedFailure, err
+	}
+
+	if do.UserSupplied != "" {
+		do.Discovered = do.UserSupplied
+		return do, DetectedFromUser, nil
+	}
+
+	df, err := FlavorFromEnv(env, plat)
+	if err != nil {
+		return do, DetectedFailure, err
+	}
+
+	do.AutoDetected = df
+	do.Discovered = do.AutoDetected
+	return do, DetectedFromCluster, nil
+}
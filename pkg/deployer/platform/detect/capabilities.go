@@ -0,0 +1,224 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package detect
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nrtv1alpha2 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha2"
+	topologyclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/clientutil"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+)
+
+const (
+	CRDNameNodeResourceTopology = "noderesourcetopologies.topology.node.k8s.io"
+	CRDNamePodGroup             = "podgroups.scheduling.sigs.k8s.io"
+
+	apiGroupSecurityOpenShift      = "security.openshift.io"
+	apiGroupMachineConfigOpenShift = "machineconfiguration.openshift.io"
+
+	// Pod Security Admission is enabled by default since kubernetes 1.23
+	minVersionPodSecurityAdmission = "1.23.0"
+
+	nrtZoneTypeNode = "Node"
+)
+
+// knownCNIs maps the name of the DaemonSets of the known CNI plugins to whether they enforce NetworkPolicies.
+// Order matters: the first match wins, so the plugins which can be layered on top of others come first.
+var knownCNIs = []struct {
+	name      string
+	daemonSet string
+	enforced  bool
+}{
+	{name: "calico", daemonSet: "calico-node", enforced: true},
+	{name: "cilium", daemonSet: "cilium", enforced: true},
+	{name: "antrea", daemonSet: "antrea-agent", enforced: true},
+	{name: "weave", daemonSet: "weave-net", enforced: true},
+	{name: "kube-router", daemonSet: "kube-router", enforced: true},
+	{name: "canal", daemonSet: "canal", enforced: true},
+	{name: "ovn-kubernetes", daemonSet: "ovnkube-node", enforced: true},
+	{name: "openshift-sdn", daemonSet: "sdn", enforced: true},
+	{name: "kube-network-policies", daemonSet: "kube-network-policies", enforced: true},
+	{name: "aws-vpc-cni", daemonSet: "aws-node", enforced: false},
+	{name: "flannel", daemonSet: "kube-flannel-ds", enforced: false},
+	{name: "kindnet", daemonSet: "kindnet", enforced: false},
+}
+
+func Capabilities(ctx context.Context) (CapabilitiesInfo, error) {
+	env := platform.Environment{
+		Environment: deployer.Environment{
+			Ctx: ctx,
+			Log: logr.Discard(),
+		},
+	}
+	if err := env.EnsureClient(); err != nil {
+		return CapabilitiesInfo{}, err
+	}
+	topoCli, err := clientutil.NewTopologyClient()
+	if err != nil {
+		return CapabilitiesInfo{}, err
+	}
+	return CapabilitiesFromEnv(&env, topoCli)
+}
+
+func CapabilitiesFromEnv(env *platform.Environment, topoCli topologyclientset.Interface) (CapabilitiesInfo, error) {
+	info := CapabilitiesInfo{}
+
+	dss := appsv1.DaemonSetList{}
+	if err := env.Cli.List(env.Ctx, &dss); err != nil {
+		return info, err
+	}
+	info.NetworkPolicy = NetworkPolicyFromDaemonSets(dss.Items)
+
+	groups, err := env.GroupsGetter.ServerGroups()
+	if err != nil {
+		return info, err
+	}
+	for _, group := range groups.Groups {
+		switch group.Name {
+		case apiGroupSecurityOpenShift:
+			info.SecurityContextConstraints = true
+		case apiGroupMachineConfigOpenShift:
+			info.MachineConfig = true
+		}
+	}
+
+	ver, err := env.DiscCli.ServerVersion()
+	if err != nil {
+		return info, err
+	}
+	info.PodSecurityAdmission = isVersionAtLeast(ver.GitVersion, minVersionPodSecurityAdmission)
+
+	info.NodeResourceTopology, err = crdInfo(env.Ctx, env.Cli, CRDNameNodeResourceTopology)
+	if err != nil {
+		return info, err
+	}
+	info.PodGroup, err = crdInfo(env.Ctx, env.Cli, CRDNamePodGroup)
+	if err != nil {
+		return info, err
+	}
+
+	if hasVersion(info.NodeResourceTopology.Versions, nrtv1alpha2.SchemeGroupVersion.Version) {
+		nrts, err := topoCli.TopologyV1alpha2().NodeResourceTopologies().List(env.Ctx, metav1.ListOptions{})
+		if err != nil {
+			return info, err
+		}
+		info.NUMA = NUMASummaryFromNRTs(nrts.Items)
+	}
+	return info, nil
+}
+
+// NetworkPolicyFromDaemonSets detects the CNI plugin from its DaemonSet, and reports if it enforces NetworkPolicies.
+func NetworkPolicyFromDaemonSets(dss []appsv1.DaemonSet) NetworkPolicyInfo {
+	names := make(map[string]bool)
+	for _, ds := range dss {
+		names[ds.Name] = true
+	}
+	for _, cni := range knownCNIs {
+		if names[cni.daemonSet] {
+			return NetworkPolicyInfo{
+				CNI:      cni.name,
+				Enforced: cni.enforced,
+			}
+		}
+	}
+	return NetworkPolicyInfo{}
+}
+
+// NUMASummaryFromNRTs summarizes the NUMA topology reported by the NRT objects.
+func NUMASummaryFromNRTs(nrts []nrtv1alpha2.NodeResourceTopology) NUMAInfo {
+	info := NUMAInfo{
+		ZonesPerNode: make(map[int]int),
+	}
+	for _, nrt := range nrts {
+		node := NodeNUMAInfo{
+			Name: nrt.Name,
+		}
+		for _, zone := range nrt.Zones {
+			if zone.Type != nrtZoneTypeNode {
+				continue
+			}
+			zi := ZoneInfo{
+				Name:      zone.Name,
+				Resources: make(map[string]string),
+			}
+			for _, res := range zone.Resources {
+				zi.Resources[res.Name] = res.Capacity.String()
+			}
+			node.Zones = append(node.Zones, zi)
+		}
+		info.ZonesPerNode[len(node.Zones)]++
+		info.Nodes = append(info.Nodes, node)
+	}
+	sort.Slice(info.Nodes, func(i, j int) bool {
+		return info.Nodes[i].Name < info.Nodes[j].Name
+	})
+	info.NodeCount = len(info.Nodes)
+	return info
+}
+
+func crdInfo(ctx context.Context, cli client.Client, name string) (CRDInfo, error) {
+	crd := apiextensionsv1.CustomResourceDefinition{}
+	err := cli.Get(ctx, client.ObjectKey{Name: name}, &crd)
+	if apierrors.IsNotFound(err) {
+		return CRDInfo{}, nil
+	}
+	if err != nil {
+		return CRDInfo{}, err
+	}
+	info := CRDInfo{
+		Installed: true,
+	}
+	for _, ver := range crd.Spec.Versions {
+		if ver.Served {
+			info.Versions = append(info.Versions, ver.Name)
+		}
+	}
+	return info, nil
+}
+
+func isVersionAtLeast(ver, minVer string) bool {
+	got, err := utilversion.ParseGeneric(strings.TrimPrefix(ver, "v"))
+	if err != nil {
+		return false
+	}
+	return got.AtLeast(utilversion.MustParseGeneric(minVer))
+}
+
+func hasVersion(versions []string, ver string) bool {
+	for _, v := range versions {
+		if v == ver {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package detect
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nrtv1alpha2 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha2"
+)
+
+func TestNetworkPolicyFromDaemonSets(t *testing.T) {
+	type testCase struct {
+		name     string
+		dsNames  []string
+		expected NetworkPolicyInfo
+	}
+
+	testCases := []testCase{
+		{
+			name:     "unknown",
+			dsNames:  []string{"kube-proxy"},
+			expected: NetworkPolicyInfo{},
+		},
+		{
+			name:     "calico",
+			dsNames:  []string{"kube-proxy", "calico-node"},
+			expected: NetworkPolicyInfo{CNI: "calico", Enforced: true},
+		},
+		{
+			name:     "kindnet",
+			dsNames:  []string{"kube-proxy", "kindnet"},
+			expected: NetworkPolicyInfo{CNI: "kindnet"},
+		},
+		{
+			name:     "calico on top of aws",
+			dsNames:  []string{"aws-node", "calico-node"},
+			expected: NetworkPolicyInfo{CNI: "calico", Enforced: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var dss []appsv1.DaemonSet
+			for _, name := range tc.dsNames {
+				dss = append(dss, appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: name}})
+			}
+			got := NetworkPolicyFromDaemonSets(dss)
+			if got != tc.expected {
+				t.Errorf("got %+v expected %+v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNUMASummaryFromNRTs(t *testing.T) {
+	nrts := []nrtv1alpha2.NodeResourceTopology{
+		makeNRT("worker-1", 2),
+		makeNRT("worker-0", 2),
+		makeNRT("worker-2", 1),
+	}
+	got := NUMASummaryFromNRTs(nrts)
+	if got.NodeCount != 3 {
+		t.Fatalf("unexpected node count: %d", got.NodeCount)
+	}
+	if !reflect.DeepEqual(got.ZonesPerNode, map[int]int{1: 1, 2: 2}) {
+		t.Errorf("unexpected zones per node: %v", got.ZonesPerNode)
+	}
+	if got.Nodes[0].Name != "worker-0" {
+		t.Errorf("nodes not sorted: %v", got.Nodes)
+	}
+	zone := got.Nodes[0].Zones[1]
+	if zone.Name != "node-1" || zone.Resources["cpu"] != "16" || zone.Resources["memory"] != "32Gi" {
+		t.Errorf("unexpected zone: %+v", zone)
+	}
+}
+
+func makeNRT(name string, numZones int) nrtv1alpha2.NodeResourceTopology {
+	nrt := nrtv1alpha2.NodeResourceTopology{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	for idx := 0; idx < numZones; idx++ {
+		nrt.Zones = append(nrt.Zones, nrtv1alpha2.Zone{
+			Name: "node-" + string(rune('0'+idx)),
+			Type: nrtZoneTypeNode,
+			Resources: nrtv1alpha2.ResourceInfoList{
+				{Name: "cpu", Capacity: resource.MustParse("16")},
+				{Name: "memory", Capacity: resource.MustParse("32Gi")},
+			},
+		})
+	}
+	// non-NUMA zones must be ignored
+	nrt.Zones = append(nrt.Zones, nrtv1alpha2.Zone{Name: "core-0", Type: "Core"})
+	return nrt
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/go-logr/logr"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
@@ -157,6 +158,22 @@ func PlatformFromEnv(env *platform.Environment) (platform.Platform, error) {
 
 }
 
+func FlavorFromEnv(env *platform.Environment, plat platform.Platform) (platform.Flavor, error) {
+	nodeList := corev1.NodeList{}
+	if err := env.Cli.List(env.Ctx, &nodeList); err != nil {
+		return platform.FlavorGeneric, err
+	}
+	groups, err := env.GroupsGetter.ServerGroups()
+	if err != nil {
+		return platform.FlavorGeneric, err
+	}
+	var groupNames []string
+	for _, group := range groups.Groups {
+		groupNames = append(groupNames, group.Name)
+	}
+	return FlavorFromNodes(plat, nodeList.Items, groupNames), nil
+}
+
 func VersionFromEnv(env *platform.Environment, plat platform.Platform) (platform.Version, error) {
 	if plat == platform.OpenShift || plat == platform.HyperShift {
 		return OpenshiftVersionFromEnv(env)
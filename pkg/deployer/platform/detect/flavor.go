@@ -0,0 +1,87 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package detect
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+)
+
+const (
+	labelInstanceType = "node.kubernetes.io/instance-type"
+
+	// served by MicroShift, which has no ClusterVersion objects unlike OpenShift
+	apiGroupOpenShiftRoute = "route.openshift.io"
+)
+
+// FlavorFromNodes infers the distribution from the node provider IDs, labels and annotations, and from the API groups
+// served by the cluster. OpenShift clusters are always Generic.
+func FlavorFromNodes(plat platform.Platform, nodes []corev1.Node, apiGroups []string) platform.Flavor {
+	if plat == platform.OpenShift || plat == platform.HyperShift {
+		return platform.FlavorGeneric
+	}
+	for _, group := range apiGroups {
+		if group == apiGroupOpenShiftRoute {
+			return platform.FlavorMicroShift
+		}
+	}
+	for _, node := range nodes {
+		if fl := flavorFromNode(node); fl != platform.FlavorGeneric {
+			return fl
+		}
+	}
+	return platform.FlavorGeneric
+}
+
+func flavorFromNode(node corev1.Node) platform.Flavor {
+	providerID := node.Spec.ProviderID
+	switch {
+	case strings.HasPrefix(providerID, "kind://"):
+		return platform.FlavorKind
+	case strings.HasPrefix(providerID, "k3s://"):
+		if node.Labels[labelInstanceType] == "rke2" || hasKeyPrefix(node.Annotations, "rke2.io/") {
+			return platform.FlavorRKE2
+		}
+		return platform.FlavorK3s
+	case strings.HasPrefix(providerID, "aws://") && hasKeyPrefix(node.Labels, "eks.amazonaws.com/"):
+		return platform.FlavorEKS
+	case strings.HasPrefix(providerID, "gce://") && hasKeyPrefix(node.Labels, "cloud.google.com/gke-"):
+		return platform.FlavorGKE
+	case strings.HasPrefix(providerID, "azure://") && hasKeyPrefix(node.Labels, "kubernetes.azure.com/"):
+		return platform.FlavorAKS
+	}
+	// custom cloud providers replace the provider ID, but the distribution leaves its marks anyway
+	switch {
+	case node.Labels[labelInstanceType] == "rke2" || hasKeyPrefix(node.Annotations, "rke2.io/"):
+		return platform.FlavorRKE2
+	case node.Labels[labelInstanceType] == "k3s" || hasKeyPrefix(node.Annotations, "k3s.io/"):
+		return platform.FlavorK3s
+	}
+	return platform.FlavorGeneric
+}
+
+func hasKeyPrefix(kvs map[string]string, prefix string) bool {
+	for key := range kvs {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
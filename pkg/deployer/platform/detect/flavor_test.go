@@ -0,0 +1,129 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package detect
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+)
+
+func TestFlavorFromNodes(t *testing.T) {
+	type testCase struct {
+		name           string
+		plat           platform.Platform
+		node           corev1.Node
+		apiGroups      []string
+		expectedFlavor platform.Flavor
+	}
+
+	testCases := []testCase{
+		{
+			name:           "generic",
+			plat:           platform.Kubernetes,
+			node:           makeNode("", nil, nil),
+			expectedFlavor: platform.FlavorGeneric,
+		},
+		{
+			name:           "kind",
+			plat:           platform.Kubernetes,
+			node:           makeNode("kind://docker/kind/kind-worker", nil, nil),
+			expectedFlavor: platform.FlavorKind,
+		},
+		{
+			name:           "k3s",
+			plat:           platform.Kubernetes,
+			node:           makeNode("k3s://node0", map[string]string{labelInstanceType: "k3s"}, nil),
+			expectedFlavor: platform.FlavorK3s,
+		},
+		{
+			name:           "rke2",
+			plat:           platform.Kubernetes,
+			node:           makeNode("k3s://node0", map[string]string{labelInstanceType: "rke2"}, nil),
+			expectedFlavor: platform.FlavorRKE2,
+		},
+		{
+			name:           "rke2 with cloud provider",
+			plat:           platform.Kubernetes,
+			node:           makeNode("aws:///us-east-1a/i-0123", nil, map[string]string{"rke2.io/node-args": "[]"}),
+			expectedFlavor: platform.FlavorRKE2,
+		},
+		{
+			name:           "eks",
+			plat:           platform.Kubernetes,
+			node:           makeNode("aws:///us-east-1a/i-0123", map[string]string{"eks.amazonaws.com/nodegroup": "ng0"}, nil),
+			expectedFlavor: platform.FlavorEKS,
+		},
+		{
+			name:           "self-managed on aws",
+			plat:           platform.Kubernetes,
+			node:           makeNode("aws:///us-east-1a/i-0123", nil, nil),
+			expectedFlavor: platform.FlavorGeneric,
+		},
+		{
+			name:           "gke",
+			plat:           platform.Kubernetes,
+			node:           makeNode("gce://project/us-central1-a/gke-node", map[string]string{"cloud.google.com/gke-nodepool": "pool0"}, nil),
+			expectedFlavor: platform.FlavorGKE,
+		},
+		{
+			name:           "aks",
+			plat:           platform.Kubernetes,
+			node:           makeNode("azure:///subscriptions/0/vm-0", map[string]string{"kubernetes.azure.com/cluster": "aks0"}, nil),
+			expectedFlavor: platform.FlavorAKS,
+		},
+		{
+			name:           "microshift",
+			plat:           platform.Kubernetes,
+			node:           makeNode("", nil, nil),
+			apiGroups:      []string{"apps", "route.openshift.io"},
+			expectedFlavor: platform.FlavorMicroShift,
+		},
+		{
+			name:           "openshift",
+			plat:           platform.OpenShift,
+			node:           makeNode("aws:///us-east-1a/i-0123", nil, nil),
+			apiGroups:      []string{"apps", "route.openshift.io"},
+			expectedFlavor: platform.FlavorGeneric,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FlavorFromNodes(tc.plat, []corev1.Node{tc.node}, tc.apiGroups)
+			if got != tc.expectedFlavor {
+				t.Errorf("detect flavor %v expected %v", got, tc.expectedFlavor)
+			}
+		})
+	}
+}
+
+func makeNode(providerID string, labels, annotations map[string]string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "node0",
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.NodeSpec{
+			ProviderID: providerID,
+		},
+	}
+}
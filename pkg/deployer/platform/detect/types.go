@@ -35,13 +35,23 @@ type VersionInfo struct {
 	Discovered   platform.Version `json:"discovered"`
 }
 
+type FlavorInfo struct {
+	AutoDetected platform.Flavor `json:"autoDetected"`
+	UserSupplied platform.Flavor `json:"userSupplied,omitempty"`
+	Discovered   platform.Flavor `json:"discovered"`
+}
+
 type ClusterInfo struct {
 	Platform PlatformInfo `json:"platform"`
 	Version  VersionInfo  `json:"version"`
+	Flavor   FlavorInfo   `json:"flavor"`
 }
 
 func (ci ClusterInfo) String() string {
-	return fmt.Sprintf("%s:%s", ci.Platform.Discovered, ci.Version.Discovered)
+	if ci.Flavor.Discovered == "" || ci.Flavor.Discovered == platform.FlavorGeneric {
+		return fmt.Sprintf("%s:%s", ci.Platform.Discovered, ci.Version.Discovered)
+	}
+	return fmt.Sprintf("%s:%s (%s)", ci.Platform.Discovered, ci.Version.Discovered, ci.Flavor.Discovered)
 }
 
 func (ci ClusterInfo) ToJSON() string {
@@ -68,6 +78,59 @@ func (cpi ControlPlaneInfo) ToJSON() string {
 	return string(data)
 }
 
+type NetworkPolicyInfo struct {
+	// CNI is the name of the detected CNI plugin, empty if unknown
+	CNI      string `json:"cni,omitempty"`
+	Enforced bool   `json:"enforced"`
+}
+
+type CRDInfo struct {
+	Installed bool `json:"installed"`
+	// Versions are the served versions
+	Versions []string `json:"versions,omitempty"`
+}
+
+type ZoneInfo struct {
+	Name string `json:"name"`
+	// Resources maps the resource names to their capacity
+	Resources map[string]string `json:"resources,omitempty"`
+}
+
+type NodeNUMAInfo struct {
+	Name  string     `json:"name"`
+	Zones []ZoneInfo `json:"zones"`
+}
+
+type NUMAInfo struct {
+	NodeCount int `json:"nodeCount"`
+	// ZonesPerNode maps the number of NUMA zones to the number of nodes having that many zones
+	ZonesPerNode map[int]int    `json:"zonesPerNode,omitempty"`
+	Nodes        []NodeNUMAInfo `json:"nodes,omitempty"`
+}
+
+type CapabilitiesInfo struct {
+	NetworkPolicy              NetworkPolicyInfo `json:"networkPolicy"`
+	SecurityContextConstraints bool              `json:"securityContextConstraints"`
+	PodSecurityAdmission       bool              `json:"podSecurityAdmission"`
+	MachineConfig              bool              `json:"machineConfig"`
+	NodeResourceTopology       CRDInfo           `json:"nodeResourceTopology"`
+	PodGroup                   CRDInfo           `json:"podGroup"`
+	NUMA                       NUMAInfo          `json:"numa"`
+}
+
+// String returns JSON as well: the capabilities are meant to be consumed by automation.
+func (ci CapabilitiesInfo) String() string {
+	return ci.ToJSON()
+}
+
+func (ci CapabilitiesInfo) ToJSON() string {
+	data, err := json.Marshal(ci)
+	if err != nil {
+		return `{"error":` + fmt.Sprintf("%q", err) + `}`
+	}
+	return string(data)
+}
+
 const (
 	DetectedFromUser    string = "user-supplied"
 	DetectedFromCluster string = "autodetected from cluster"
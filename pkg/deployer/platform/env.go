@@ -42,22 +42,28 @@ type ClusterVersionsLister interface {
 
 type Environment struct {
 	deployer.Environment
-	DiscCli     discovery.ServerVersionInterface
-	COGetter    ClusterOperatorsGetter
-	CVLister    ClusterVersionsLister
-	InfraGetter InfrastructuresGetter
+	DiscCli      discovery.ServerVersionInterface
+	GroupsGetter discovery.ServerGroupsInterface
+	COGetter     ClusterOperatorsGetter
+	CVLister     ClusterVersionsLister
+	InfraGetter  InfrastructuresGetter
 }
 
 func (env *Environment) EnsureClient() error {
 	if err := env.Environment.EnsureClient(); err != nil {
 		return err
 	}
-	if env.DiscCli == nil {
+	if env.DiscCli == nil || env.GroupsGetter == nil {
 		cli, err := clientutil.NewDiscoveryClient()
 		if err != nil {
 			return err
 		}
-		env.DiscCli = cli
+		if env.DiscCli == nil {
+			env.DiscCli = cli
+		}
+		if env.GroupsGetter == nil {
+			env.GroupsGetter = cli
+		}
 	}
 
 	var err error
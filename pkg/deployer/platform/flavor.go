@@ -0,0 +1,120 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package platform
+
+import "strings"
+
+// Flavor is the kubernetes distribution running on the platform. Distributions differ in the host paths
+// and in what is allowed on the control plane.
+type Flavor string
+
+const (
+	// FlavorGeneric is any distribution not needing special handling
+	FlavorGeneric    = Flavor("Generic")
+	FlavorKind       = Flavor("Kind")
+	FlavorK3s        = Flavor("K3s")
+	FlavorRKE2       = Flavor("RKE2")
+	FlavorMicroShift = Flavor("MicroShift")
+	FlavorEKS        = Flavor("EKS")
+	FlavorGKE        = Flavor("GKE")
+	FlavorAKS        = Flavor("AKS")
+)
+
+func (f Flavor) String() string {
+	return string(f)
+}
+
+func ParseFlavor(flavor string) (Flavor, bool) {
+	for _, fl := range Flavors() {
+		if strings.EqualFold(flavor, string(fl)) {
+			return fl, true
+		}
+	}
+	return FlavorGeneric, false
+}
+
+// Flavors returns all the known flavors.
+func Flavors() []Flavor {
+	return []Flavor{
+		FlavorGeneric,
+		FlavorKind,
+		FlavorK3s,
+		FlavorRKE2,
+		FlavorMicroShift,
+		FlavorEKS,
+		FlavorGKE,
+		FlavorAKS,
+	}
+}
+
+const (
+	DefaultKubeletDir        = "/var/lib/kubelet"
+	DefaultKubeletConfigFile = "config.yaml"
+	DefaultPodResourcesDir   = "/var/lib/kubelet/pod-resources"
+)
+
+// FlavorDefaults are the settings depending on the flavor
+type FlavorDefaults struct {
+	// KubeletConfigDir is the host directory containing the kubelet configuration file
+	KubeletConfigDir string
+	// KubeletConfigFile is the name of the kubelet configuration file in KubeletConfigDir. On K3s and RKE2 it holds
+	// only the distribution defaults: the settings passed with --kubelet-arg are not there, so the topology and
+	// CPU manager settings must be set in a drop-in file selected with DaemonSet.KubeletConfigFile.
+	KubeletConfigFile string
+	// PodResourcesDir is the host directory containing the kubelet podresources socket
+	PodResourcesDir string
+	// SysfsMountPropagation requires the host sysfs to be mounted propagating the host mounts.
+	// Needed when the nodes are containers themselves, and the real sysfs is mounted over the container one.
+	SysfsMountPropagation bool
+	// CtrlPlaneAffinity is true if workloads can be pinned to the control plane nodes.
+	// Managed services don't expose the control plane nodes.
+	CtrlPlaneAffinity bool
+}
+
+// Defaults returns the settings to use for the flavor.
+func (f Flavor) Defaults() FlavorDefaults {
+	fd := FlavorDefaults{
+		KubeletConfigDir:  DefaultKubeletDir,
+		KubeletConfigFile: DefaultKubeletConfigFile,
+		PodResourcesDir:   DefaultPodResourcesDir,
+		CtrlPlaneAffinity: true,
+	}
+	switch f {
+	case FlavorKind:
+		fd.SysfsMountPropagation = true
+	case FlavorK3s:
+		fd.KubeletConfigDir = "/var/lib/rancher/k3s/agent/etc/kubelet.conf.d"
+		fd.KubeletConfigFile = "00-k3s-defaults.conf"
+	case FlavorRKE2:
+		fd.KubeletConfigDir = "/var/lib/rancher/rke2/agent/etc/kubelet.conf.d"
+		fd.KubeletConfigFile = "00-rke2-defaults.conf"
+	case FlavorMicroShift:
+		fd.KubeletConfigDir = "/var/lib/microshift/resources/kubelet/config"
+	case FlavorEKS, FlavorGKE, FlavorAKS:
+		fd.CtrlPlaneAffinity = false
+	}
+	return fd
+}
+
+// WithKubeletConfigFile returns the defaults reading the kubelet configuration from the given file
+// in KubeletConfigDir. Empty keeps the flavor default.
+func (fd FlavorDefaults) WithKubeletConfigFile(name string) FlavorDefaults {
+	if name != "" {
+		fd.KubeletConfigFile = name
+	}
+	return fd
+}
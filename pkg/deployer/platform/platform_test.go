@@ -16,7 +16,10 @@
 
 package platform
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestRoudnTrip(t *testing.T) {
 	type testCase struct {
@@ -65,3 +68,20 @@ func TestRoudnTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestFlavorRoundTrip(t *testing.T) {
+	for _, fl := range Flavors() {
+		t.Run(fl.String(), func(t *testing.T) {
+			got, ok := ParseFlavor(strings.ToLower(fl.String()))
+			if !ok {
+				t.Fatalf("%q: not parsed", fl)
+			}
+			if got != fl {
+				t.Errorf("%q: got=%q", fl, got)
+			}
+		})
+	}
+	if _, ok := ParseFlavor("foobar"); ok {
+		t.Errorf("unexpected flavor parsed")
+	}
+}
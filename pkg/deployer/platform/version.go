@@ -16,7 +16,16 @@
 
 package platform
 
-import goversion "github.com/aquasecurity/go-version/pkg/version"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	goversion "github.com/aquasecurity/go-version/pkg/version"
+)
+
+// openShiftKubernetesMinorOffset relates the OpenShift and the kubernetes minor versions: OpenShift 4.Y ships kubernetes 1.(Y+13)
+const openShiftKubernetesMinorOffset = 13
 
 type Version string
 
@@ -49,3 +58,19 @@ func (v Version) AtLeastString(other string) (bool, error) {
 func (v Version) AtLeast(other Version) (bool, error) {
 	return v.AtLeastString(other.String())
 }
+
+// KubernetesVersion returns the kubernetes version the given platform version is based on.
+func KubernetesVersion(plat Platform, ver Version) (Version, error) {
+	if plat != OpenShift && plat != HyperShift {
+		return ver, nil
+	}
+	items := strings.Split(strings.TrimPrefix(ver.String(), "v"), ".")
+	if len(items) < 2 || items[0] != "4" {
+		return MissingVersion, fmt.Errorf("unsupported %s version: %q", plat, ver)
+	}
+	minor, err := strconv.Atoi(items[1])
+	if err != nil {
+		return MissingVersion, fmt.Errorf("unsupported %s version: %q: %w", plat, ver, err)
+	}
+	return Version(fmt.Sprintf("1.%d", minor+openShiftKubernetesMinorOffset)), nil
+}
@@ -0,0 +1,50 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package platform
+
+import "testing"
+
+func TestKubernetesVersion(t *testing.T) {
+	type testCase struct {
+		plat          Platform
+		ver           Version
+		expected      Version
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{plat: Kubernetes, ver: "v1.28.3", expected: "v1.28.3"},
+		{plat: OpenShift, ver: "4.14", expected: "1.27"},
+		{plat: OpenShift, ver: "v4.16.2", expected: "1.29"},
+		{plat: HyperShift, ver: "4.15.0", expected: "1.28"},
+		{plat: OpenShift, ver: "3.11", expectedError: true},
+		{plat: OpenShift, ver: "4", expectedError: true},
+		{plat: OpenShift, ver: MissingVersion, expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.plat)+"/"+string(tc.ver), func(t *testing.T) {
+			got, err := KubernetesVersion(tc.plat, tc.ver)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("got error %v, expected error %v", err, tc.expectedError)
+			}
+			if got != tc.expected {
+				t.Errorf("got=%q expected=%q", got, tc.expected)
+			}
+		})
+	}
+}
@@ -19,39 +19,58 @@ package sched
 import (
 	"fmt"
 
+	"github.com/go-logr/logr"
+
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	schedmanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/sched"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate"
 	schedwait "github.com/k8stopologyawareschedwg/deployer/pkg/objectwait/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/patches"
 )
 
 func SetupNamespace(plat platform.Platform) (*corev1.Namespace, string, error) {
 	return nil, "", fmt.Errorf("not yet implemented")
 }
 
-func Deploy(env *deployer.Environment, opts options.Scheduler) error {
-	var err error
-	env = env.WithName("SCD")
-	env.Log.Info("deploying topology-aware-scheduling scheduler plugin")
-
+// GetManifests renders the scheduler plugin manifests, then applies the user patches and the image mirrors.
+// Render, deploy and remove all use it, so they always act on the same objects.
+func GetManifests(logger logr.Logger, opts options.Scheduler, namespace string) (schedmanifests.Manifests, error) {
 	mf, err := schedmanifests.NewWithOptions(options.Render{
-		Platform: opts.Platform,
+		Platform:  opts.Platform,
+		Namespace: namespace,
 	})
 	if err != nil {
-		return err
+		return mf, err
+	}
+	ret, err := mf.Render(logger, opts)
+	if err != nil {
+		return ret, err
 	}
+	if err := patches.Apply(ret.ToObjects(), opts.Patches); err != nil {
+		return ret, err
+	}
+	objectupdate.RewriteImages(ret.ToObjects(), opts.ImageMirrors)
+	return ret, nil
+}
 
-	mf, err = mf.Render(env.Log, opts)
+func Deploy(env *deployer.Environment, opts options.Scheduler) error {
+	var err error
+	env = env.WithName("SCD")
+	env.Log.Info("deploying topology-aware-scheduling scheduler plugin")
+
+	mf, err := GetManifests(env.Log, opts, "")
 	if err != nil {
 		return err
 	}
 	env.Log.V(3).Info("manifests loaded")
 
+	createObject := env.CreateObjectFunc(opts.Adopt)
 	for _, wo := range schedwait.Creatable(mf, env.Cli, env.Log) {
-		if err := env.CreateObject(wo.Obj); err != nil {
+		if err := createObject(wo.Obj); err != nil {
 			return err
 		}
 
@@ -74,14 +93,7 @@ func Remove(env *deployer.Environment, opts options.Scheduler) error {
 	env = env.WithName("SCD")
 	env.Log.Info("removing topology-aware-scheduling scheduler plugin")
 
-	mf, err := schedmanifests.NewWithOptions(options.Render{
-		Platform: opts.Platform,
-	})
-	if err != nil {
-		return err
-	}
-
-	mf, err = mf.Render(env.Log, opts)
+	mf, err := GetManifests(env.Log, opts, "")
 	if err != nil {
 		return err
 	}
@@ -24,30 +24,24 @@ import (
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
 	nfdmanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/nfd"
 	rtemanifests "github.com/k8stopologyawareschedwg/deployer/pkg/manifests/rte"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/objectwait"
 	nfdwait "github.com/k8stopologyawareschedwg/deployer/pkg/objectwait/nfd"
 	rtewait "github.com/k8stopologyawareschedwg/deployer/pkg/objectwait/rte"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/patches"
 )
 
 func GetObjects(opts options.Updater, updaterType, namespace string) ([]client.Object, error) {
 	if updaterType == RTE {
-		mf, err := rtemanifests.NewWithOptions(renderOptionsFrom(opts, namespace))
-		if err != nil {
-			return nil, err
-		}
-		ret, err := mf.Render(updaterDaemonOptionsFrom(opts, namespace))
+		ret, err := renderRTE(opts, namespace)
 		if err != nil {
 			return nil, err
 		}
 		return ret.ToObjects(), nil
 	}
 	if updaterType == NFD {
-		mf, err := nfdmanifests.NewWithOptions(renderOptionsFrom(opts, namespace))
-		if err != nil {
-			return nil, err
-		}
-		ret, err := mf.Render(updaterDaemonOptionsFrom(opts, namespace))
+		ret, err := renderNFD(opts, namespace)
 		if err != nil {
 			return nil, err
 		}
@@ -58,22 +52,17 @@ func GetObjects(opts options.Updater, updaterType, namespace string) ([]client.O
 
 func getCreatableObjects(env *deployer.Environment, opts options.Updater, updaterType, namespace string) ([]objectwait.WaitableObject, error) {
 	if updaterType == RTE {
-		mf, err := rtemanifests.NewWithOptions(renderOptionsFrom(opts, namespace))
+		ret, err := renderRTE(opts, namespace)
 		if err != nil {
 			return nil, err
 		}
-		ret, err := mf.Render(updaterDaemonOptionsFrom(opts, namespace))
-		if err != nil {
-			return nil, err
+		if ret.NodePoolConfigMap != nil {
+			env.Log.Info("the SELinux policy must be applied through the NodePool configuration on the management cluster, use render to get it", "configMap", ret.NodePoolConfigMap.Name, "namespace", ret.NodePoolConfigMap.Namespace)
 		}
 		return rtewait.Creatable(ret, env.Cli, env.Log), nil
 	}
 	if updaterType == NFD {
-		mf, err := nfdmanifests.NewWithOptions(renderOptionsFrom(opts, namespace))
-		if err != nil {
-			return nil, err
-		}
-		ret, err := mf.Render(updaterDaemonOptionsFrom(opts, namespace))
+		ret, err := renderNFD(opts, namespace)
 		if err != nil {
 			return nil, err
 		}
@@ -84,22 +73,14 @@ func getCreatableObjects(env *deployer.Environment, opts options.Updater, update
 
 func getDeletableObjects(env *deployer.Environment, opts options.Updater, updaterType, namespace string) ([]objectwait.WaitableObject, error) {
 	if updaterType == RTE {
-		mf, err := rtemanifests.NewWithOptions(renderOptionsFrom(opts, namespace))
-		if err != nil {
-			return nil, err
-		}
-		ret, err := mf.Render(updaterDaemonOptionsFrom(opts, namespace))
+		ret, err := renderRTE(opts, namespace)
 		if err != nil {
 			return nil, err
 		}
 		return rtewait.Deletable(ret, env.Cli, env.Log), nil
 	}
 	if updaterType == NFD {
-		mf, err := nfdmanifests.NewWithOptions(renderOptionsFrom(opts, namespace))
-		if err != nil {
-			return nil, err
-		}
-		ret, err := mf.Render(updaterDaemonOptionsFrom(opts, namespace))
+		ret, err := renderNFD(opts, namespace)
 		if err != nil {
 			return nil, err
 		}
@@ -108,11 +89,47 @@ func getDeletableObjects(env *deployer.Environment, opts options.Updater, update
 	return nil, fmt.Errorf("unsupported updater: %q", updaterType)
 }
 
+func renderRTE(opts options.Updater, namespace string) (rtemanifests.Manifests, error) {
+	mf, err := rtemanifests.NewWithOptions(renderOptionsFrom(opts, namespace))
+	if err != nil {
+		return mf, err
+	}
+	ret, err := mf.Render(updaterDaemonOptionsFrom(opts, namespace))
+	if err != nil {
+		return ret, err
+	}
+	if err := patches.Apply(ret.ToObjects(), opts.Patches); err != nil {
+		return ret, err
+	}
+	objectupdate.RewriteImages(ret.ToObjects(), opts.ImageMirrors)
+	return ret, nil
+}
+
+func renderNFD(opts options.Updater, namespace string) (nfdmanifests.Manifests, error) {
+	mf, err := nfdmanifests.NewWithOptions(renderOptionsFrom(opts, namespace))
+	if err != nil {
+		return mf, err
+	}
+	ret, err := mf.Render(updaterDaemonOptionsFrom(opts, namespace))
+	if err != nil {
+		return ret, err
+	}
+	if err := patches.Apply(ret.ToObjects(), opts.Patches); err != nil {
+		return ret, err
+	}
+	objectupdate.RewriteImages(ret.ToObjects(), opts.ImageMirrors)
+	return ret, nil
+}
+
 func updaterDaemonOptionsFrom(opts options.Updater, namespace string) options.UpdaterDaemon {
 	return options.UpdaterDaemon{
-		ConfigData: opts.RTEConfigData,
-		DaemonSet:  opts.DaemonSet,
-		Namespace:  namespace,
+		ConfigData:            opts.RTEConfigData,
+		DaemonSet:             opts.DaemonSet,
+		Namespace:             namespace,
+		NodePoolNamespace:     opts.NodePoolNamespace,
+		ImagePullSecretSource: opts.ImagePullSecretSource,
+		Metrics:               opts.Metrics,
+		NetworkPolicies:       opts.NetworkPolicies,
 	}
 }
 
@@ -25,6 +25,7 @@ import (
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/wait"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/objectwait"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
@@ -42,6 +43,7 @@ func Deploy(env *deployer.Environment, updaterType string, opts options.Updater)
 	if err != nil {
 		return err
 	}
+	SetupNamespaceMonitoring(ns, opts)
 
 	objs, err := getCreatableObjects(env, opts, updaterType, namespace)
 	if err != nil {
@@ -52,8 +54,9 @@ func Deploy(env *deployer.Environment, updaterType string, opts options.Updater)
 
 	objs = append([]objectwait.WaitableObject{{Obj: ns}}, objs...)
 
+	createObject := env.CreateObjectFunc(opts.Adopt)
 	for _, wo := range objs {
-		if err := env.CreateObject(wo.Obj); err != nil {
+		if err := createObject(wo.Obj); err != nil {
 			return err
 		}
 
@@ -76,7 +79,7 @@ func Remove(env *deployer.Environment, updaterType string, opts options.Updater)
 	env = env.WithName(updaterType)
 	env.Log.Info("removing topology-aware-scheduling topology updater")
 
-	ns, err := manifests.Namespace(updaterTypeAsComponent(updaterType))
+	ns, err := manifests.Namespace(ComponentFromType(updaterType))
 	if err != nil {
 		return err
 	}
@@ -113,15 +116,24 @@ func Remove(env *deployer.Environment, updaterType string, opts options.Updater)
 	return nil
 }
 
+// SetupNamespaceMonitoring labels the namespace to be scraped by the selected monitoring stack, if any
+func SetupNamespaceMonitoring(ns *corev1.Namespace, opts options.Updater) {
+	if opts.Metrics.Monitor == "" {
+		return
+	}
+	objectupdate.SetObjectLabels(ns, manifests.MonitoringNamespaceLabels(opts.Platform, opts.Metrics.Stack))
+}
+
 func SetupNamespace(updaterType string) (*corev1.Namespace, string, error) {
-	ns, err := manifests.Namespace(updaterTypeAsComponent(updaterType))
+	ns, err := manifests.Namespace(ComponentFromType(updaterType))
 	if err != nil {
 		return nil, "", err
 	}
 	return ns, ns.Name, nil
 }
 
-func updaterTypeAsComponent(updaterType string) string {
+// ComponentFromType returns the manifests component of the updater type
+func ComponentFromType(updaterType string) string {
 	// this relation is loose, but we're validating it before use
 	return strings.ToLower(updaterType)
 }
@@ -0,0 +1,152 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package images
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImageSet is a set of component images known to work together, along with the kubernetes versions they support.
+type ImageSet struct {
+	// Name identifies the set, after the scheduler plugins release
+	Name string
+	// KubernetesVersions lists the supported kubernetes minor versions, like "1.27".
+	// The scheduler plugins are built against a kubernetes version, and can run on the same
+	// or on the next minor version, following the kube-scheduler version skew policy.
+	KubernetesVersions []string
+	Tag                Images
+	// SHA holds the images pinned by digest. Sets without digests can't be used when digests are requested.
+	SHA Images
+}
+
+// ImageSets are the bundled image sets, newest first.
+var ImageSets = []ImageSet{
+	{
+		Name:               "v0.27.8",
+		KubernetesVersions: []string{"1.27", "1.28"},
+		Tag:                Defaults(false),
+		SHA:                Defaults(true),
+	},
+	{
+		Name:               "v0.26.7",
+		KubernetesVersions: []string{"1.26", "1.27"},
+		Tag: Images{
+			SchedulerPluginScheduler:  "registry.k8s.io/scheduler-plugins/kube-scheduler:v0.26.7",
+			SchedulerPluginController: "registry.k8s.io/scheduler-plugins/controller:v0.26.7",
+			ResourceTopologyExporter:  "quay.io/k8stopologyawareschedwg/resource-topology-exporter:v0.14.0",
+			NodeFeatureDiscovery:      "registry.k8s.io/nfd/node-feature-discovery:v0.14.0",
+			Pause:                     PauseDefaultImageTag,
+		},
+	},
+}
+
+// Images returns the images of the set, pinned by digest if requested.
+// Returns error if digests are requested but the set has none.
+func (is ImageSet) Images(useSHA bool) (Images, error) {
+	if !useSHA {
+		return is.Tag, nil
+	}
+	if is.SHA == (Images{}) {
+		return Images{}, fmt.Errorf("image set %q has no images pinned by digest, use the tag images", is.Name)
+	}
+	return is.SHA, nil
+}
+
+// Supports tells if the set can run on the given kubernetes version.
+func (is ImageSet) Supports(kubeVersion string) bool {
+	minor, ok := kubernetesMinorVersion(kubeVersion)
+	if !ok {
+		return false
+	}
+	for _, ver := range is.KubernetesVersions {
+		if ver == minor {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectImageSet returns the newest bundled image set supporting the given kubernetes version.
+func SelectImageSet(kubeVersion string) (ImageSet, bool) {
+	for _, is := range ImageSets {
+		if is.Supports(kubeVersion) {
+			return is, true
+		}
+	}
+	return ImageSet{}, false
+}
+
+// GetForKubernetesVersion is like Get, but starts from the newest image set supporting the given kubernetes version.
+// Returns false, along with the images Get returns, if no bundled set supports that version.
+// Returns error if digests are requested but the selected set has none.
+func GetForKubernetesVersion(kubeVersion string) (Images, ImageSet, bool, error) {
+	is, ok := SelectImageSet(kubeVersion)
+	if !ok {
+		return Get(), is, false, nil
+	}
+	_, useSHA := os.LookupEnv("TAS_IMAGES_USE_SHA")
+	imgs, err := is.Images(useSHA)
+	if err != nil {
+		return Images{}, is, true, err
+	}
+	return imgs.WithOverrides(os.LookupEnv), is, true, nil
+}
+
+// SupportedKubernetesVersions returns the kubernetes minor versions supported by any bundled image set, oldest first.
+func SupportedKubernetesVersions() []string {
+	var vers []string
+	seen := make(map[string]bool)
+	for _, is := range ImageSets {
+		for _, ver := range is.KubernetesVersions {
+			if seen[ver] {
+				continue
+			}
+			seen[ver] = true
+			vers = append(vers, ver)
+		}
+	}
+	sort.Slice(vers, func(i, j int) bool {
+		mi, _ := strconv.Atoi(strings.TrimPrefix(vers[i], "1."))
+		mj, _ := strconv.Atoi(strings.TrimPrefix(vers[j], "1."))
+		return mi < mj
+	})
+	return vers
+}
+
+// kubernetesMinorVersion reduces versions like "v1.28.3+k3s1" to their "major.minor" form.
+func kubernetesMinorVersion(ver string) (string, bool) {
+	items := strings.Split(strings.TrimPrefix(ver, "v"), ".")
+	if len(items) < 2 {
+		return "", false
+	}
+	major, err := strconv.Atoi(items[0])
+	if err != nil {
+		return "", false
+	}
+	if idx := strings.IndexFunc(items[1], func(r rune) bool { return r < '0' || r > '9' }); idx != -1 {
+		items[1] = items[1][:idx] // e.g. "1.28+k3s1"
+	}
+	minor, err := strconv.Atoi(items[1])
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("%d.%d", major, minor), true
+}
@@ -0,0 +1,86 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package images
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelectImageSet(t *testing.T) {
+	type testCase struct {
+		kubeVersion  string
+		expectedName string
+		expectedOK   bool
+	}
+
+	testCases := []testCase{
+		{kubeVersion: "1.28", expectedName: "v0.27.8", expectedOK: true},
+		{kubeVersion: "v1.28.3+k3s1", expectedName: "v0.27.8", expectedOK: true},
+		{kubeVersion: "1.28+", expectedName: "v0.27.8", expectedOK: true},
+		{kubeVersion: "v1.27.1", expectedName: "v0.27.8", expectedOK: true},
+		{kubeVersion: "v1.26.5", expectedName: "v0.26.7", expectedOK: true},
+		{kubeVersion: "1.25"},
+		{kubeVersion: "1.30"},
+		{kubeVersion: "1"},
+		{kubeVersion: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.kubeVersion, func(t *testing.T) {
+			is, ok := SelectImageSet(tc.kubeVersion)
+			if ok != tc.expectedOK || is.Name != tc.expectedName {
+				t.Errorf("got=%q,%v expected=%q,%v", is.Name, ok, tc.expectedName, tc.expectedOK)
+			}
+		})
+	}
+}
+
+func TestImageSets(t *testing.T) {
+	if !reflect.DeepEqual(ImageSets[0].Tag, Defaults(false)) || !reflect.DeepEqual(ImageSets[0].SHA, Defaults(true)) {
+		t.Errorf("the newest image set must hold the default images")
+	}
+	for _, is := range ImageSets {
+		for _, useSHA := range []bool{false, true} {
+			imgs, err := is.Images(useSHA)
+			if err != nil {
+				if useSHA && is.SHA == (Images{}) {
+					continue
+				}
+				t.Fatalf("image set %q (sha=%v): %v", is.Name, useSHA, err)
+			}
+			if imgs.SchedulerPluginScheduler == "" || imgs.SchedulerPluginController == "" || imgs.ResourceTopologyExporter == "" || imgs.NodeFeatureDiscovery == "" || imgs.Pause == "" {
+				t.Errorf("image set %q (sha=%v): missing images: %+v", is.Name, useSHA, imgs)
+			}
+		}
+		kubeVer, ok := SchedulerPluginKubernetesVersion(is.Tag.SchedulerPluginScheduler)
+		if !ok || !is.Supports(kubeVer) {
+			t.Errorf("image set %q does not support the kubernetes version its scheduler is built against (%q)", is.Name, kubeVer)
+		}
+	}
+	if _, err := ImageSets[1].Images(true); err == nil {
+		t.Errorf("image set without digests: expected error requesting digests")
+	}
+}
+
+func TestSupportedKubernetesVersions(t *testing.T) {
+	got := SupportedKubernetesVersions()
+	expected := []string{"1.26", "1.27", "1.28"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got=%v expected=%v", got, expected)
+	}
+}
@@ -21,6 +21,7 @@ const (
 	SchedulerPluginControllerDefaultImageTag = "registry.k8s.io/scheduler-plugins/controller:v0.27.8"
 	NodeFeatureDiscoveryDefaultImageTag      = "registry.k8s.io/nfd/node-feature-discovery:v0.15.1"
 	ResourceTopologyExporterDefaultImageTag  = "quay.io/k8stopologyawareschedwg/resource-topology-exporter:v0.19.3"
+	PauseDefaultImageTag                     = "registry.k8s.io/pause:3.9"
 )
 
 const (
@@ -28,4 +29,10 @@ const (
 	SchedulerPluginControllerDefaultImageSHA = "registry.k8s.io/scheduler-plugins/controller@sha256:b616f088ab5d5c70b7faa17d08837c8e54ad0e5fef4d7c6a304f70bfd3b89b55"
 	NodeFeatureDiscoveryDefaultImageSHA      = "registry.k8s.io/nfd/node-feature-discovery@sha256:cab8506a76c96a4318d4cb1858ead6fe55a2e0499f69b4201b01d69d4fa14f10"
 	ResourceTopologyExporterDefaultImageSHA  = "quay.io/k8stopologyawareschedwg/resource-topology-exporter@sha256:6e9b01d6b18a38909d523082a06f1a626161f58c72fd0a8f17db2dae9f5e14c3"
+	PauseDefaultImageSHA                     = "registry.k8s.io/pause@sha256:7031c1b283388d2c2e09b57badb803c05ebed362dc88d84b480cc47f72a21097"
+)
+
+const (
+	// SchedulerPluginDefaultKubernetesVersion is the kubernetes version the default scheduler plugin images are built against
+	SchedulerPluginDefaultKubernetesVersion = "1.27"
 )
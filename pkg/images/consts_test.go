@@ -33,4 +33,7 @@ func TestImageURISanity(t *testing.T) {
 	if imgs.NodeFeatureDiscovery == "" {
 		t.Fatalf("invalid Node Feature Discovery Image pull URL")
 	}
+	if imgs.Pause == "" {
+		t.Fatalf("invalid Pause Image pull URL")
+	}
 }
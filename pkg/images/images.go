@@ -16,13 +16,20 @@
 
 package images
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
 
 type Images struct {
 	SchedulerPluginScheduler  string
 	SchedulerPluginController string
 	ResourceTopologyExporter  string
 	NodeFeatureDiscovery      string
+	// Pause is used by the RTE shared pool container and by the validation test pod
+	Pause string
 }
 
 func Defaults(useSHA bool) Images {
@@ -32,6 +39,7 @@ func Defaults(useSHA bool) Images {
 			SchedulerPluginController: SchedulerPluginControllerDefaultImageSHA,
 			ResourceTopologyExporter:  ResourceTopologyExporterDefaultImageSHA,
 			NodeFeatureDiscovery:      NodeFeatureDiscoveryDefaultImageSHA,
+			Pause:                     PauseDefaultImageSHA,
 		}
 	}
 	return Images{
@@ -39,6 +47,7 @@ func Defaults(useSHA bool) Images {
 		SchedulerPluginController: SchedulerPluginControllerDefaultImageTag,
 		ResourceTopologyExporter:  ResourceTopologyExporterDefaultImageTag,
 		NodeFeatureDiscovery:      NodeFeatureDiscoveryDefaultImageTag,
+		Pause:                     PauseDefaultImageTag,
 	}
 }
 
@@ -48,7 +57,12 @@ func Get() Images {
 }
 
 func GetWithFunc(useSHA bool, getImage func(string) (string, bool)) Images {
-	images := Defaults(useSHA)
+	return Defaults(useSHA).WithOverrides(getImage)
+}
+
+// WithOverrides returns the images replaced by the ones getImage provides, looked up by their TAS_* variable name.
+func (imgs Images) WithOverrides(getImage func(string) (string, bool)) Images {
+	images := imgs
 	if schedImage, ok := getImage("TAS_SCHEDULER_PLUGIN_IMAGE"); ok {
 		images.SchedulerPluginScheduler = schedImage
 	}
@@ -61,5 +75,64 @@ func GetWithFunc(useSHA bool, getImage func(string) (string, bool)) Images {
 	if nfdImage, ok := getImage("TAS_NODE_FEATURE_DISCOVERY_IMAGE"); ok {
 		images.NodeFeatureDiscovery = nfdImage
 	}
+	if pauseImage, ok := getImage("TAS_PAUSE_IMAGE"); ok {
+		images.Pause = pauseImage
+	}
 	return images
 }
+
+// OrGet returns the images, or the ones Get returns if none is set.
+func (imgs Images) OrGet() Images {
+	if imgs == (Images{}) {
+		return Get()
+	}
+	return imgs
+}
+
+// Contains tells if the image is one of the images of the set.
+func (imgs Images) Contains(image string) bool {
+	for _, img := range []string{
+		imgs.SchedulerPluginScheduler,
+		imgs.SchedulerPluginController,
+		imgs.ResourceTopologyExporter,
+		imgs.NodeFeatureDiscovery,
+		imgs.Pause,
+	} {
+		if img == image {
+			return true
+		}
+	}
+	return false
+}
+
+// SchedulerPluginKubernetesVersion infers the kubernetes version the scheduler plugin image is built against.
+// scheduler-plugins releases track the kubernetes minor version: v0.27.x is built against kubernetes 1.27.
+// Returns false if the version cannot be inferred, e.g. for images referenced by digest.
+func SchedulerPluginKubernetesVersion(image string) (string, bool) {
+	if image == SchedulerPluginSchedulerDefaultImageTag || image == SchedulerPluginSchedulerDefaultImageSHA {
+		return SchedulerPluginDefaultKubernetesVersion, true
+	}
+	if strings.Contains(image, "@") {
+		return "", false
+	}
+	idx := strings.LastIndex(image, ":")
+	if idx == -1 || strings.Contains(image[idx:], "/") {
+		return "", false
+	}
+	items := strings.Split(strings.TrimPrefix(image[idx+1:], "v"), ".")
+	if len(items) < 2 {
+		return "", false
+	}
+	major, err := strconv.Atoi(items[0])
+	if err != nil {
+		return "", false
+	}
+	minor, err := strconv.Atoi(items[1])
+	if err != nil {
+		return "", false
+	}
+	if major == 0 {
+		major = 1
+	}
+	return fmt.Sprintf("%d.%d", major, minor), true
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package images
+
+import "testing"
+
+func TestSchedulerPluginKubernetesVersion(t *testing.T) {
+	type testCase struct {
+		name            string
+		image           string
+		expectedVersion string
+		expectedOK      bool
+	}
+
+	testCases := []testCase{
+		{
+			name:            "default tag",
+			image:           SchedulerPluginSchedulerDefaultImageTag,
+			expectedVersion: SchedulerPluginDefaultKubernetesVersion,
+			expectedOK:      true,
+		},
+		{
+			name:            "default digest",
+			image:           SchedulerPluginSchedulerDefaultImageSHA,
+			expectedVersion: SchedulerPluginDefaultKubernetesVersion,
+			expectedOK:      true,
+		},
+		{
+			name:            "scheduler-plugins release",
+			image:           "registry.k8s.io/scheduler-plugins/kube-scheduler:v0.24.9",
+			expectedVersion: "1.24",
+			expectedOK:      true,
+		},
+		{
+			name:            "kubernetes release",
+			image:           "quay.io/example/kube-scheduler:v1.30.2",
+			expectedVersion: "1.30",
+			expectedOK:      true,
+		},
+		{
+			name:            "registry with port",
+			image:           "localhost:5000/kube-scheduler:v0.29.7",
+			expectedVersion: "1.29",
+			expectedOK:      true,
+		},
+		{
+			name:  "registry with port, no tag",
+			image: "localhost:5000/kube-scheduler",
+		},
+		{
+			name:  "digest",
+			image: "quay.io/example/kube-scheduler@sha256:5b1e96f23e87f6e38e2e31062cdc705d34728dc8181b3b78298d689e30156dbc",
+		},
+		{
+			name:  "latest",
+			image: "quay.io/example/kube-scheduler:latest",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := SchedulerPluginKubernetesVersion(tc.image)
+			if ok != tc.expectedOK || got != tc.expectedVersion {
+				t.Errorf("got %q (%v) expected %q (%v)", got, ok, tc.expectedVersion, tc.expectedOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package images
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Mirror redirects the images pulled from the Source registry or repository to the Mirror one.
+type Mirror struct {
+	Source string `json:"source"`
+	Mirror string `json:"mirror"`
+}
+
+func (mr Mirror) String() string {
+	return mr.Source + "=" + mr.Mirror
+}
+
+// ParseMirror parses a mirror rule in the form "source=mirror", e.g. "registry.k8s.io=mirror.example.com/k8s".
+// Both sides are a registry or a repository prefix, without tag or digest.
+func ParseMirror(spec string) (Mirror, error) {
+	source, mirror, ok := strings.Cut(strings.TrimSpace(spec), "=")
+	if !ok {
+		return Mirror{}, fmt.Errorf("mirror %q: expected \"source=mirror\"", spec)
+	}
+	mr := Mirror{
+		Source: strings.TrimSuffix(source, "/"),
+		Mirror: strings.TrimSuffix(mirror, "/"),
+	}
+	for _, loc := range []string{mr.Source, mr.Mirror} {
+		if err := validateLocation(loc); err != nil {
+			return Mirror{}, fmt.Errorf("mirror %q: %w", spec, err)
+		}
+	}
+	return mr, nil
+}
+
+func validateLocation(loc string) error {
+	if loc == "" {
+		return fmt.Errorf("empty location")
+	}
+	if strings.Contains(loc, "://") {
+		return fmt.Errorf("location %q: unexpected scheme", loc)
+	}
+	if strings.Contains(loc, "@") {
+		return fmt.Errorf("location %q: unexpected digest", loc)
+	}
+	// a colon is allowed only in the registry host, to set the port
+	if _, repo, ok := strings.Cut(loc, "/"); ok && strings.Contains(repo, ":") {
+		return fmt.Errorf("location %q: unexpected tag", loc)
+	}
+	return nil
+}
+
+type Mirrors []Mirror
+
+// Rewrite returns the image pulled from the mirror of the most specific matching source.
+// Returns false if no source matches.
+func (ms Mirrors) Rewrite(image string) (string, bool) {
+	best := -1
+	for idx, mr := range ms {
+		if !matchesSource(image, mr.Source) {
+			continue
+		}
+		if best == -1 || len(mr.Source) > len(ms[best].Source) {
+			best = idx
+		}
+	}
+	if best == -1 {
+		return image, false
+	}
+	return ms[best].Mirror + image[len(ms[best].Source):], true
+}
+
+// Mapping returns the "source=mirror" image pairs for the given images, sorted, skipping the images
+// with no matching source. The output is suitable to mirror the images, e.g. with `oc image mirror -f`.
+func (ms Mirrors) Mapping(imageList []string) List {
+	var ret List
+	for _, image := range imageList {
+		mirrored, ok := ms.Rewrite(image)
+		if !ok {
+			continue
+		}
+		ret = append(ret, image+"="+mirrored)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Used returns the mirrors matching at least one of the given images, sorted by source.
+func (ms Mirrors) Used(imageList []string) Mirrors {
+	var ret Mirrors
+	for _, mr := range ms {
+		for _, image := range imageList {
+			if matchesSource(image, mr.Source) {
+				ret = append(ret, mr)
+				break
+			}
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Source < ret[j].Source
+	})
+	return ret
+}
+
+// matchesSource tells if the image is pulled from the source registry or repository, on a path boundary
+func matchesSource(image, source string) bool {
+	if !strings.HasPrefix(image, source) {
+		return false
+	}
+	if len(image) == len(source) {
+		return true
+	}
+	switch image[len(source)] {
+	case '/':
+		return true
+	case ':', '@':
+		// a colon right after a registry host introduces the port, not the tag
+		return strings.Contains(source, "/")
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package images
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMirror(t *testing.T) {
+	type testCase struct {
+		name          string
+		spec          string
+		expected      Mirror
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "registry",
+			spec:     "registry.k8s.io=mirror.example.com:5000/k8s/",
+			expected: Mirror{Source: "registry.k8s.io", Mirror: "mirror.example.com:5000/k8s"},
+		},
+		{
+			name:     "repository",
+			spec:     "gcr.io/google_containers=mirror.example.com/gcr",
+			expected: Mirror{Source: "gcr.io/google_containers", Mirror: "mirror.example.com/gcr"},
+		},
+		{
+			name:          "missing mirror",
+			spec:          "registry.k8s.io",
+			expectedError: true,
+		},
+		{
+			name:          "empty source",
+			spec:          "=mirror.example.com",
+			expectedError: true,
+		},
+		{
+			name:          "tag",
+			spec:          "registry.k8s.io/pause:3.9=mirror.example.com",
+			expectedError: true,
+		},
+		{
+			name:          "scheme",
+			spec:          "registry.k8s.io=https://mirror.example.com",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseMirror(tc.spec)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("got=%#v expected=%#v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMirrorsRewrite(t *testing.T) {
+	mirrors := Mirrors{
+		{Source: "registry.k8s.io", Mirror: "mirror.example.com/k8s"},
+		{Source: "registry.k8s.io/nfd", Mirror: "mirror.example.com/nfd"},
+		{Source: "gcr.io/google_containers/pause-amd64", Mirror: "mirror.example.com/pause"},
+	}
+
+	type testCase struct {
+		name       string
+		image      string
+		expected   string
+		expectedOK bool
+	}
+
+	testCases := []testCase{
+		{
+			name:       "registry",
+			image:      SchedulerPluginSchedulerDefaultImageTag,
+			expected:   "mirror.example.com/k8s/scheduler-plugins/kube-scheduler:v0.27.8",
+			expectedOK: true,
+		},
+		{
+			name:       "most specific source",
+			image:      NodeFeatureDiscoveryDefaultImageSHA,
+			expected:   "mirror.example.com/nfd/node-feature-discovery@sha256:cab8506a76c96a4318d4cb1858ead6fe55a2e0499f69b4201b01d69d4fa14f10",
+			expectedOK: true,
+		},
+		{
+			name:       "repository",
+			image:      "gcr.io/google_containers/pause-amd64:3.0",
+			expected:   "mirror.example.com/pause:3.0",
+			expectedOK: true,
+		},
+		{
+			name:     "no match",
+			image:    ResourceTopologyExporterDefaultImageTag,
+			expected: ResourceTopologyExporterDefaultImageTag,
+		},
+		{
+			name:     "partial path component",
+			image:    "registry.k8s.io.example.com/foo:v1",
+			expected: "registry.k8s.io.example.com/foo:v1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := mirrors.Rewrite(tc.image)
+			if ok != tc.expectedOK {
+				t.Errorf("ok got=%v expected=%v", ok, tc.expectedOK)
+			}
+			if got != tc.expected {
+				t.Errorf("image got=%q expected=%q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMirrorsMapping(t *testing.T) {
+	mirrors := Mirrors{
+		{Source: "registry.k8s.io", Mirror: "mirror.example.com/k8s"},
+		{Source: "docker.io", Mirror: "mirror.example.com/dh"},
+	}
+	imageList := []string{
+		SchedulerPluginSchedulerDefaultImageTag,
+		ResourceTopologyExporterDefaultImageTag,
+		SchedulerPluginControllerDefaultImageTag,
+	}
+
+	got := mirrors.Mapping(imageList)
+	expected := List{
+		"registry.k8s.io/scheduler-plugins/controller:v0.27.8=mirror.example.com/k8s/scheduler-plugins/controller:v0.27.8",
+		"registry.k8s.io/scheduler-plugins/kube-scheduler:v0.27.8=mirror.example.com/k8s/scheduler-plugins/kube-scheduler:v0.27.8",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("mapping got=%v expected=%v", got, expected)
+	}
+
+	used := mirrors.Used(imageList)
+	if !reflect.DeepEqual(used, mirrors[:1]) {
+		t.Errorf("used mirrors got=%v expected=%v", used, mirrors[:1])
+	}
+}
+
+func TestMirrorSets(t *testing.T) {
+	mirrors := Mirrors{
+		{Source: "registry.k8s.io", Mirror: "mirror1.example.com/k8s"},
+		{Source: "quay.io", Mirror: "mirror1.example.com/quay"},
+		{Source: "registry.k8s.io", Mirror: "mirror2.example.com/k8s"},
+	}
+
+	idms := mirrors.ImageDigestMirrorSet()
+	if len(idms.Spec.ImageDigestMirrors) != 2 {
+		t.Fatalf("unexpected digest mirrors: %v", idms.Spec.ImageDigestMirrors)
+	}
+	if got := idms.Spec.ImageDigestMirrors[0]; got.Source != "registry.k8s.io" || len(got.Mirrors) != 2 {
+		t.Errorf("unexpected digest mirrors for the first source: %v", got)
+	}
+
+	itms := mirrors.ImageTagMirrorSet()
+	if len(itms.Spec.ImageTagMirrors) != 2 {
+		t.Fatalf("unexpected tag mirrors: %v", itms.Spec.ImageTagMirrors)
+	}
+
+	icsp := mirrors.ImageContentSourcePolicy()
+	repoMirrors, ok := icsp.Object["spec"].(map[string]interface{})["repositoryDigestMirrors"].([]interface{})
+	if !ok || len(repoMirrors) != 2 {
+		t.Errorf("unexpected repository digest mirrors: %v", icsp.Object["spec"])
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package images
+
+import (
+	ocpconfigv1 "github.com/openshift/api/config/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// MirrorSetName is the name of the OpenShift objects configuring the mirrors
+	MirrorSetName = "topology-aware-scheduling"
+)
+
+// ImageDigestMirrorSet configures the mirrors on OpenShift 4.13 and newer for the images pulled by digest.
+func (ms Mirrors) ImageDigestMirrorSet() *ocpconfigv1.ImageDigestMirrorSet {
+	idms := &ocpconfigv1.ImageDigestMirrorSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ImageDigestMirrorSet",
+			APIVersion: ocpconfigv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: MirrorSetName,
+		},
+	}
+	for _, source := range ms.sources() {
+		idms.Spec.ImageDigestMirrors = append(idms.Spec.ImageDigestMirrors, ocpconfigv1.ImageDigestMirrors{
+			Source:  source,
+			Mirrors: ms.mirrorsOf(source),
+		})
+	}
+	return idms
+}
+
+// ImageTagMirrorSet configures the mirrors on OpenShift 4.13 and newer for the images pulled by tag.
+func (ms Mirrors) ImageTagMirrorSet() *ocpconfigv1.ImageTagMirrorSet {
+	itms := &ocpconfigv1.ImageTagMirrorSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ImageTagMirrorSet",
+			APIVersion: ocpconfigv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: MirrorSetName,
+		},
+	}
+	for _, source := range ms.sources() {
+		itms.Spec.ImageTagMirrors = append(itms.Spec.ImageTagMirrors, ocpconfigv1.ImageTagMirrors{
+			Source:  source,
+			Mirrors: ms.mirrorsOf(source),
+		})
+	}
+	return itms
+}
+
+// ImageContentSourcePolicy configures the mirrors on OpenShift older than 4.13, for the images pulled by digest only.
+// The operator API is not vendored, so the object is unstructured.
+func (ms Mirrors) ImageContentSourcePolicy() *unstructured.Unstructured {
+	var repoMirrors []interface{}
+	for _, source := range ms.sources() {
+		var mirrors []interface{}
+		for _, mirror := range ms.mirrorsOf(source) {
+			mirrors = append(mirrors, string(mirror))
+		}
+		repoMirrors = append(repoMirrors, map[string]interface{}{
+			"source":  source,
+			"mirrors": mirrors,
+		})
+	}
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "operator.openshift.io/v1alpha1",
+			"kind":       "ImageContentSourcePolicy",
+			"metadata": map[string]interface{}{
+				"name": MirrorSetName,
+			},
+			"spec": map[string]interface{}{
+				"repositoryDigestMirrors": repoMirrors,
+			},
+		},
+	}
+}
+
+// sources returns the distinct sources, in order of appearance
+func (ms Mirrors) sources() []string {
+	var ret []string
+	seen := make(map[string]bool)
+	for _, mr := range ms {
+		if seen[mr.Source] {
+			continue
+		}
+		seen[mr.Source] = true
+		ret = append(ret, mr.Source)
+	}
+	return ret
+}
+
+func (ms Mirrors) mirrorsOf(source string) []ocpconfigv1.ImageMirror {
+	var ret []ocpconfigv1.ImageMirror
+	for _, mr := range ms {
+		if mr.Source == source {
+			ret = append(ret, ocpconfigv1.ImageMirror(mr.Mirror))
+		}
+	}
+	return ret
+}
@@ -33,6 +33,7 @@ type Output struct {
 	TopologyUpdater     string `json:"topology_updater"`
 	SchedulerPlugin     string `json:"scheduler_plugin"`
 	SchedulerController string `json:"scheduler_controller"`
+	Pause               string `json:"pause"`
 }
 
 func NewOutput(imgs Images, updaterType string) Output {
@@ -40,6 +41,7 @@ func NewOutput(imgs Images, updaterType string) Output {
 		SchedulerPlugin:     imgs.SchedulerPluginScheduler,
 		SchedulerController: imgs.SchedulerPluginController,
 		TopologyUpdater:     getUpdaterImage(imgs, updaterType),
+		Pause:               imgs.Pause,
 	}
 	return imo
 }
@@ -51,6 +53,7 @@ func (imo Output) ToList() List {
 		imo.TopologyUpdater,
 		imo.SchedulerPlugin,
 		imo.SchedulerController,
+		imo.Pause,
 	}
 }
 
@@ -83,6 +86,7 @@ func (imo Output) EncodeText(w io.Writer) {
 	fmt.Fprintf(w, "TAS_SCHEDULER_PLUGIN_IMAGE=%s\n", imo.SchedulerPlugin)
 	fmt.Fprintf(w, "TAS_SCHEDULER_PLUGIN_CONTROLLER_IMAGE=%s\n", imo.SchedulerController)
 	fmt.Fprintf(w, "TAS_RESOURCE_EXPORTER_IMAGE=%s\n", imo.TopologyUpdater)
+	fmt.Fprintf(w, "TAS_PAUSE_IMAGE=%s\n", imo.Pause)
 }
 
 func (imo Output) EncodeJSON(w io.Writer) {
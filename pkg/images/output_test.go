@@ -27,7 +27,7 @@ func TestOutputBasics(t *testing.T) {
 
 	imo := NewOutput(imgs, "foobar")
 	images := imo.ToList()
-	if len(images) != 3 {
+	if len(images) != 4 {
 		t.Errorf("unexpected image list content: %#v", images)
 	}
 }
@@ -45,25 +45,25 @@ func TestOutput(t *testing.T) {
 			name:        "text/rte",
 			kind:        FormatText,
 			updaterType: "RTE",
-			expected:    "TAS_SCHEDULER_PLUGIN_IMAGE=sched_sched\nTAS_SCHEDULER_PLUGIN_CONTROLLER_IMAGE=sched_ctrl\nTAS_RESOURCE_EXPORTER_IMAGE=rte",
+			expected:    "TAS_SCHEDULER_PLUGIN_IMAGE=sched_sched\nTAS_SCHEDULER_PLUGIN_CONTROLLER_IMAGE=sched_ctrl\nTAS_RESOURCE_EXPORTER_IMAGE=rte\nTAS_PAUSE_IMAGE=pause",
 		},
 		{
 			name:        "text/nfd",
 			kind:        FormatText,
 			updaterType: "NFD",
-			expected:    "TAS_SCHEDULER_PLUGIN_IMAGE=sched_sched\nTAS_SCHEDULER_PLUGIN_CONTROLLER_IMAGE=sched_ctrl\nTAS_RESOURCE_EXPORTER_IMAGE=nfd",
+			expected:    "TAS_SCHEDULER_PLUGIN_IMAGE=sched_sched\nTAS_SCHEDULER_PLUGIN_CONTROLLER_IMAGE=sched_ctrl\nTAS_RESOURCE_EXPORTER_IMAGE=nfd\nTAS_PAUSE_IMAGE=pause",
 		},
 		{
 			name:        "json/rte",
 			kind:        FormatJSON,
 			updaterType: "RTE",
-			expected:    `{"topology_updater":"rte","scheduler_plugin":"sched_sched","scheduler_controller":"sched_ctrl"}`,
+			expected:    `{"topology_updater":"rte","scheduler_plugin":"sched_sched","scheduler_controller":"sched_ctrl","pause":"pause"}`,
 		},
 		{
 			name:        "json/nfd",
 			kind:        FormatJSON,
 			updaterType: "NFD",
-			expected:    `{"topology_updater":"nfd","scheduler_plugin":"sched_sched","scheduler_controller":"sched_ctrl"}`,
+			expected:    `{"topology_updater":"nfd","scheduler_plugin":"sched_sched","scheduler_controller":"sched_ctrl","pause":"pause"}`,
 		},
 	}
 
@@ -92,6 +92,8 @@ func testGetImage(key string) (string, bool) {
 		return "rte", true
 	case "TAS_NODE_FEATURE_DISCOVERY_IMAGE":
 		return "nfd", true
+	case "TAS_PAUSE_IMAGE":
+		return "pause", true
 	default:
 		return "", false
 	}
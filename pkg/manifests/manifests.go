@@ -63,11 +63,14 @@ const (
 
 const (
 	ContainerNameRTE                = "resource-topology-exporter"
+	ContainerNameSharedPool         = "shared-pool-container"
 	ContainerNameNFDTopologyUpdater = "nfd-topology-updater"
 )
 const (
 	DefaultUpdaterSyncPeriod = 10 * time.Second
 	DefaultUpdaterVerbose    = 1
+	// DefaultAPIServerPort is the port the pods can reach the API server on, if it was not discovered
+	DefaultAPIServerPort = 6443
 )
 
 const (
@@ -95,6 +98,7 @@ func init() {
 	securityv1.Install(scheme.Scheme)
 }
 
+// Namespace returns the namespace of the component, labeled with the pod security level its pods need
 func Namespace(component string) (*corev1.Namespace, error) {
 	if err := validateComponent(component); err != nil {
 		return nil, err
@@ -109,6 +113,12 @@ func Namespace(component string) (*corev1.Namespace, error) {
 	if !ok {
 		return nil, fmt.Errorf("unexpected type, got %t", obj)
 	}
+	if ns.Labels == nil {
+		ns.Labels = make(map[string]string)
+	}
+	for key, value := range PodSecurityLabels(PodSecurityLevel(component)) {
+		ns.Labels[key] = value
+	}
 	return ns, nil
 }
 
@@ -18,6 +18,8 @@ package manifests
 
 import (
 	"encoding/json"
+	"io/fs"
+	"strings"
 	"testing"
 
 	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
@@ -25,6 +27,7 @@ import (
 
 	selinuxassets "github.com/k8stopologyawareschedwg/deployer/pkg/assets/selinux"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
 )
 
 func TestGetNamespace(t *testing.T) {
@@ -661,3 +664,35 @@ func TestNetworkPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestEmbeddedImagesManaged(t *testing.T) {
+	managed := images.Defaults(false)
+	found := 0
+	err := fs.WalkDir(src, "yaml", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := src.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			image, ok := strings.CutPrefix(strings.TrimSpace(line), "image:")
+			if !ok {
+				continue
+			}
+			image = strings.Trim(strings.TrimSpace(image), `"'`)
+			if !managed.Contains(image) {
+				t.Errorf("%s: image %q is not managed by the images package", path, image)
+			}
+			found++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walking the embedded manifests failed: %v", err)
+	}
+	if found == 0 {
+		t.Errorf("no image found in the embedded manifests")
+	}
+}
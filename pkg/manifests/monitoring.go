@@ -0,0 +1,412 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+)
+
+const (
+	MonitorServiceMonitor = "ServiceMonitor"
+	MonitorPodMonitor     = "PodMonitor"
+)
+
+const (
+	// MonitoringStackCluster is the OpenShift platform monitoring stack
+	MonitoringStackCluster = "cluster"
+	// MonitoringStackUserWorkload is the OpenShift monitoring stack for user-defined projects
+	MonitoringStackUserWorkload = "user-workload"
+)
+
+const (
+	monitoringAPIVersion   = "monitoring.coreos.com/v1"
+	metricsServicePortName = "metrics"
+
+	labelClusterMonitoring = "openshift.io/cluster-monitoring"
+	labelUserMonitoring    = "openshift.io/user-monitoring"
+	labelMetricsService    = "app.kubernetes.io/name"
+
+	annotationServingCertSecret = "service.beta.openshift.io/serving-cert-secret-name"
+
+	// the service CA bundle the OpenShift service CA operator injects in every namespace
+	serviceCAConfigMapName = "openshift-service-ca.crt"
+	serviceCAConfigMapKey  = "service-ca.crt"
+	// the CA key of the kubernetes.io/tls secrets issued by cert-manager and similar tools
+	tlsSecretCAKey = "ca.crt"
+)
+
+// ParseMonitorKind normalizes the monitor kind, case insensitive. Empty means no monitor.
+func ParseMonitorKind(kind string) (string, error) {
+	switch strings.ToLower(kind) {
+	case "":
+		return "", nil
+	case strings.ToLower(MonitorServiceMonitor):
+		return MonitorServiceMonitor, nil
+	case strings.ToLower(MonitorPodMonitor):
+		return MonitorPodMonitor, nil
+	default:
+		return "", fmt.Errorf("unsupported monitor kind %q", kind)
+	}
+}
+
+func ParseMonitoringStack(stack string) (string, error) {
+	switch stack {
+	case MonitoringStackCluster, MonitoringStackUserWorkload:
+		return stack, nil
+	default:
+		return "", fmt.Errorf("unsupported monitoring stack %q", stack)
+	}
+}
+
+// DefaultMetricsScraper returns the "namespace/name" service account of the Prometheus instance scraping the metrics.
+// Outside OpenShift, it is the kube-prometheus default.
+func DefaultMetricsScraper(plat platform.Platform, stack string) string {
+	if plat != platform.OpenShift && plat != platform.HyperShift {
+		return "monitoring/prometheus-k8s"
+	}
+	if stack == MonitoringStackUserWorkload {
+		return "openshift-user-workload-monitoring/prometheus-user-workload"
+	}
+	return "openshift-monitoring/prometheus-k8s"
+}
+
+// MonitoringNamespaceLabels returns the labels making the OpenShift monitoring stack scrape the monitors of a namespace.
+func MonitoringNamespaceLabels(plat platform.Platform, stack string) map[string]string {
+	if plat != platform.OpenShift && plat != platform.HyperShift {
+		return nil
+	}
+	if stack == MonitoringStackUserWorkload {
+		// the user workload stack ignores the namespaces of the cluster stack
+		return map[string]string{
+			labelClusterMonitoring: "false",
+			labelUserMonitoring:    "true",
+		}
+	}
+	return map[string]string{
+		labelClusterMonitoring: "true",
+	}
+}
+
+// MetricsEndpoint describes the metrics served by the pods of a component.
+type MetricsEndpoint struct {
+	Namespace string
+	// Name is the base name of the monitoring objects
+	Name string
+	// Selector matches the labels of the pods serving the metrics
+	Selector map[string]string
+	Port     int32
+	// PortName is the name of the container port serving the metrics
+	PortName string
+	// TLS endpoints serve HTTPS
+	TLS bool
+	// Secure endpoints serve HTTPS and authorize the scraper using a service account token
+	Secure bool
+	// ServingCertSecret, if set, asks the OpenShift service CA operator to generate the serving certificate in this secret
+	ServingCertSecret string
+	// CA verifies the serving certificate of the TLS and secure endpoints. Without it, the verification is skipped
+	// only if InsecureSkipVerify is set.
+	CA                 *MetricsCA
+	InsecureSkipVerify bool
+}
+
+// MetricsCA references the CA bundle verifying a metrics serving certificate, in the ConfigMap or the Secret
+// of the component namespace.
+type MetricsCA struct {
+	ConfigMap string
+	Secret    string
+	Key       string
+}
+
+// ServiceCA is the CA bundle of the OpenShift service CA operator, which signs the ServingCertSecret certificates
+func ServiceCA() *MetricsCA {
+	return &MetricsCA{ConfigMap: serviceCAConfigMapName, Key: serviceCAConfigMapKey}
+}
+
+// SecretCA is the CA bundle stored along with the serving certificate in a kubernetes.io/tls secret
+func SecretCA(secretName string) *MetricsCA {
+	return &MetricsCA{Secret: secretName, Key: tlsSecretCAKey}
+}
+
+// ServerName is the name the serving certificate is verified against: the DNS name of the metrics Service
+func (ep MetricsEndpoint) ServerName() string {
+	return fmt.Sprintf("%s-metrics.%s.svc", ep.Name, ep.Namespace)
+}
+
+// Monitoring holds the objects the Prometheus Operator needs to scrape the metrics of a component.
+type Monitoring struct {
+	// Role and RoleBinding let the scraper discover the targets in the component namespace
+	Role        *rbacv1.Role
+	RoleBinding *rbacv1.RoleBinding
+	// ClusterRole and ClusterRoleBinding let the ServiceAccount read the secure metrics endpoints.
+	// The monitor presents the token of the ServiceAccount, stored in TokenSecret.
+	ClusterRole        *rbacv1.ClusterRole
+	ClusterRoleBinding *rbacv1.ClusterRoleBinding
+	ServiceAccount     *corev1.ServiceAccount
+	TokenSecret        *corev1.Secret
+	// Service is rendered only for ServiceMonitors
+	Service *corev1.Service
+	// Monitor is a ServiceMonitor or a PodMonitor. The Prometheus Operator API is not vendored, so the object is unstructured.
+	Monitor *unstructured.Unstructured
+}
+
+// NewMonitoring creates the objects to scrape the endpoint with a monitor of the given kind.
+// Secure endpoints are always scraped through a ServiceMonitor, presenting the token of a dedicated service account
+// through a secret: the monitors can't reference files, which the OpenShift user workload monitoring forbids.
+// So are the endpoints whose certificate is generated by the service CA operator, which needs the Service.
+// The scraper is the "namespace/name" service account of Prometheus.
+func NewMonitoring(ep MetricsEndpoint, kind, scraper string) (*Monitoring, error) {
+	scraperNamespace, scraperName, ok := strings.Cut(scraper, "/")
+	if !ok || scraperNamespace == "" || scraperName == "" {
+		return nil, fmt.Errorf("malformed metrics scraper %q: expected \"namespace/name\"", scraper)
+	}
+	if ep.Secure || ep.ServingCertSecret != "" {
+		kind = MonitorServiceMonitor
+	}
+	if (ep.Secure || ep.TLS) && ep.CA == nil && !ep.InsecureSkipVerify {
+		return nil, fmt.Errorf("metrics endpoint %s/%s: no CA to verify the serving certificate", ep.Namespace, ep.Name)
+	}
+	subject := rbacv1.Subject{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      scraperName,
+		Namespace: scraperNamespace,
+	}
+
+	mon := &Monitoring{
+		Role: &rbacv1.Role{
+			TypeMeta:   metav1.TypeMeta{Kind: "Role", APIVersion: rbacv1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: ep.Name + "-prometheus", Namespace: ep.Namespace},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{""},
+					Resources: []string{"services", "endpoints", "pods"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+				{
+					APIGroups: []string{"discovery.k8s.io"},
+					Resources: []string{"endpointslices"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+			},
+		},
+		RoleBinding: &rbacv1.RoleBinding{
+			TypeMeta:   metav1.TypeMeta{Kind: "RoleBinding", APIVersion: rbacv1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: ep.Name + "-prometheus", Namespace: ep.Namespace},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     ep.Name + "-prometheus",
+			},
+			Subjects: []rbacv1.Subject{subject},
+		},
+	}
+
+	if ep.Secure {
+		mon.ServiceAccount = &corev1.ServiceAccount{
+			TypeMeta:   metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: ep.Name + "-metrics-scraper", Namespace: ep.Namespace},
+		}
+		mon.TokenSecret = &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ep.Name + "-metrics-scraper-token",
+				Namespace: ep.Namespace,
+				Annotations: map[string]string{
+					corev1.ServiceAccountNameKey: mon.ServiceAccount.Name,
+				},
+			},
+			Type: corev1.SecretTypeServiceAccountToken,
+		}
+		mon.ClusterRole = &rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{Kind: "ClusterRole", APIVersion: rbacv1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: ep.Name + "-metrics-reader"},
+			Rules: []rbacv1.PolicyRule{
+				{
+					NonResourceURLs: []string{"/metrics"},
+					Verbs:           []string{"get"},
+				},
+			},
+		}
+		mon.ClusterRoleBinding = &rbacv1.ClusterRoleBinding{
+			TypeMeta:   metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: rbacv1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: ep.Name + "-metrics-reader"},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     ep.Name + "-metrics-reader",
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      mon.ServiceAccount.Name,
+					Namespace: mon.ServiceAccount.Namespace,
+				},
+			},
+		}
+	}
+
+	endpoint := map[string]interface{}{
+		"path":   "/metrics",
+		"scheme": "http",
+	}
+	if ep.Secure || ep.TLS {
+		endpoint["scheme"] = "https"
+		endpoint["tlsConfig"] = tlsConfigFor(ep)
+	}
+	if ep.Secure {
+		endpoint["authorization"] = map[string]interface{}{
+			"type": "Bearer",
+			"credentials": map[string]interface{}{
+				"name": mon.TokenSecret.Name,
+				"key":  corev1.ServiceAccountTokenKey,
+			},
+		}
+	}
+
+	selector := make(map[string]interface{}, len(ep.Selector))
+	for key, value := range ep.Selector {
+		selector[key] = value
+	}
+
+	if kind == MonitorPodMonitor {
+		endpoint["port"] = ep.PortName
+		mon.Monitor = newMonitor(MonitorPodMonitor, ep, map[string]interface{}{
+			"selector":            map[string]interface{}{"matchLabels": selector},
+			"podMetricsEndpoints": []interface{}{endpoint},
+		})
+		return mon, nil
+	}
+
+	mon.Service = NewMetricsService(ep)
+	endpoint["port"] = metricsServicePortName
+	mon.Monitor = newMonitor(MonitorServiceMonitor, ep, map[string]interface{}{
+		"selector":  map[string]interface{}{"matchLabels": map[string]interface{}{labelMetricsService: ep.Name + "-metrics"}},
+		"endpoints": []interface{}{endpoint},
+	})
+	return mon, nil
+}
+
+func tlsConfigFor(ep MetricsEndpoint) map[string]interface{} {
+	if ep.CA == nil {
+		return map[string]interface{}{
+			"insecureSkipVerify": true,
+		}
+	}
+	ref := map[string]interface{}{
+		"key": ep.CA.Key,
+	}
+	ca := map[string]interface{}{}
+	if ep.CA.ConfigMap != "" {
+		ref["name"] = ep.CA.ConfigMap
+		ca["configMap"] = ref
+	} else {
+		ref["name"] = ep.CA.Secret
+		ca["secret"] = ref
+	}
+	return map[string]interface{}{
+		"ca":         ca,
+		"serverName": ep.ServerName(),
+	}
+}
+
+// NewMetricsService creates the headless Service selecting the pods serving the metrics
+func NewMetricsService(ep MetricsEndpoint) *corev1.Service {
+	svc := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ep.Name + "-metrics",
+			Namespace: ep.Namespace,
+			Labels:    map[string]string{labelMetricsService: ep.Name + "-metrics"},
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  ep.Selector,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       metricsServicePortName,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       ep.Port,
+					TargetPort: intstr.FromString(ep.PortName),
+				},
+			},
+		},
+	}
+	if ep.ServingCertSecret != "" {
+		svc.Annotations = map[string]string{
+			annotationServingCertSecret: ep.ServingCertSecret,
+		}
+	}
+	return svc
+}
+
+func newMonitor(kind string, ep MetricsEndpoint, spec map[string]interface{}) *unstructured.Unstructured {
+	spec["namespaceSelector"] = map[string]interface{}{
+		"matchNames": []interface{}{ep.Namespace},
+	}
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": monitoringAPIVersion,
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      ep.Name,
+				"namespace": ep.Namespace,
+			},
+			"spec": spec,
+		},
+	}
+}
+
+// DeepCopy returns a deep copy of the monitoring objects. Nil-safe.
+func (mon *Monitoring) DeepCopy() *Monitoring {
+	if mon == nil {
+		return nil
+	}
+	return &Monitoring{
+		Role:               mon.Role.DeepCopy(),
+		RoleBinding:        mon.RoleBinding.DeepCopy(),
+		ClusterRole:        mon.ClusterRole.DeepCopy(),
+		ClusterRoleBinding: mon.ClusterRoleBinding.DeepCopy(),
+		ServiceAccount:     mon.ServiceAccount.DeepCopy(),
+		TokenSecret:        mon.TokenSecret.DeepCopy(),
+		Service:            mon.Service.DeepCopy(),
+		Monitor:            mon.Monitor.DeepCopy(),
+	}
+}
+
+// ToObjects returns the monitoring objects, in creation order. Nil-safe.
+func (mon *Monitoring) ToObjects() []client.Object {
+	if mon == nil {
+		return nil
+	}
+	objs := []client.Object{mon.Role, mon.RoleBinding}
+	if mon.ClusterRole != nil {
+		objs = append(objs, mon.ServiceAccount, mon.TokenSecret, mon.ClusterRole, mon.ClusterRoleBinding)
+	}
+	if mon.Service != nil {
+		objs = append(objs, mon.Service)
+	}
+	return append(objs, mon.Monitor)
+}
@@ -0,0 +1,224 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+)
+
+func TestNewMonitoring(t *testing.T) {
+	ep := MetricsEndpoint{
+		Namespace: "tas",
+		Name:      "exporter",
+		Selector:  map[string]string{"name": "exporter"},
+		Port:      2112,
+		PortName:  "metrics-port",
+	}
+	secureEp := ep
+	secureEp.Secure = true
+	secureEp.CA = ServiceCA()
+
+	testCases := []struct {
+		name            string
+		ep              MetricsEndpoint
+		kind            string
+		expectedKind    string
+		expectedService bool
+		expectedCluster bool
+	}{
+		{
+			name:            "service monitor",
+			ep:              ep,
+			kind:            MonitorServiceMonitor,
+			expectedKind:    MonitorServiceMonitor,
+			expectedService: true,
+		},
+		{
+			name:         "pod monitor",
+			ep:           ep,
+			kind:         MonitorPodMonitor,
+			expectedKind: MonitorPodMonitor,
+		},
+		{
+			name:            "secure endpoint forces service monitor",
+			ep:              secureEp,
+			kind:            MonitorPodMonitor,
+			expectedKind:    MonitorServiceMonitor,
+			expectedService: true,
+			expectedCluster: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mon, err := NewMonitoring(tc.ep, tc.kind, "monitoring/prometheus-k8s")
+			if err != nil {
+				t.Fatalf("NewMonitoring() failed: %v", err)
+			}
+			if mon.Monitor.GetKind() != tc.expectedKind {
+				t.Errorf("got monitor kind %q expected %q", mon.Monitor.GetKind(), tc.expectedKind)
+			}
+			if mon.Monitor.GetNamespace() != tc.ep.Namespace {
+				t.Errorf("got monitor namespace %q expected %q", mon.Monitor.GetNamespace(), tc.ep.Namespace)
+			}
+			if (mon.Service != nil) != tc.expectedService {
+				t.Errorf("service rendered=%v expected=%v", mon.Service != nil, tc.expectedService)
+			}
+			if (mon.ClusterRole != nil) != tc.expectedCluster {
+				t.Errorf("cluster role rendered=%v expected=%v", mon.ClusterRole != nil, tc.expectedCluster)
+			}
+			subjects := mon.RoleBinding.Subjects
+			if len(subjects) != 1 || subjects[0].Namespace != "monitoring" || subjects[0].Name != "prometheus-k8s" {
+				t.Errorf("unexpected role binding subjects: %v", subjects)
+			}
+
+			endpointsKey := "endpoints"
+			if tc.expectedKind == MonitorPodMonitor {
+				endpointsKey = "podMetricsEndpoints"
+			}
+			endpoints, ok, err := unstructured.NestedSlice(mon.Monitor.Object, "spec", endpointsKey)
+			if err != nil || !ok || len(endpoints) != 1 {
+				t.Fatalf("unexpected monitor endpoints: %v (ok=%v err=%v)", endpoints, ok, err)
+			}
+			endpoint := endpoints[0].(map[string]interface{})
+			scheme, _, _ := unstructured.NestedString(endpoint, "scheme")
+			if tc.ep.Secure != (scheme == "https") {
+				t.Errorf("unexpected scheme %q for secure=%v", scheme, tc.ep.Secure)
+			}
+			if _, ok := endpoint["bearerTokenFile"]; ok {
+				t.Errorf("monitor references the scraper filesystem: %v", endpoint)
+			}
+			if !tc.ep.Secure {
+				return
+			}
+			caName, _, _ := unstructured.NestedString(endpoint, "tlsConfig", "ca", "configMap", "name")
+			serverName, _, _ := unstructured.NestedString(endpoint, "tlsConfig", "serverName")
+			if caName != serviceCAConfigMapName || serverName != "exporter-metrics.tas.svc" {
+				t.Errorf("serving certificate not verified: %v", endpoint["tlsConfig"])
+			}
+			if skip, _, _ := unstructured.NestedBool(endpoint, "tlsConfig", "insecureSkipVerify"); skip {
+				t.Errorf("secure endpoint skips the certificate verification: %v", endpoint["tlsConfig"])
+			}
+			tokenSecret, _, _ := unstructured.NestedString(endpoint, "authorization", "credentials", "name")
+			if mon.TokenSecret == nil || tokenSecret != mon.TokenSecret.Name || mon.TokenSecret.Namespace != tc.ep.Namespace {
+				t.Errorf("unexpected authorization token secret %q: %v", tokenSecret, mon.TokenSecret)
+			}
+			if mon.TokenSecret.Annotations[corev1.ServiceAccountNameKey] != mon.ServiceAccount.Name {
+				t.Errorf("token secret not bound to the service account: %v", mon.TokenSecret.Annotations)
+			}
+			crbSubjects := mon.ClusterRoleBinding.Subjects
+			if len(crbSubjects) != 1 || crbSubjects[0].Name != mon.ServiceAccount.Name || crbSubjects[0].Namespace != tc.ep.Namespace {
+				t.Errorf("unexpected cluster role binding subjects: %v", crbSubjects)
+			}
+		})
+	}
+}
+
+func TestNewMonitoringTLSVerification(t *testing.T) {
+	ep := MetricsEndpoint{
+		Namespace: "tas",
+		Name:      "exporter",
+		Selector:  map[string]string{"name": "exporter"},
+		Port:      2112,
+		PortName:  "metrics-port",
+		TLS:       true,
+	}
+
+	type testCase struct {
+		name            string
+		ca              *MetricsCA
+		insecure        bool
+		expectedError   bool
+		expectedTLSConf map[string]interface{}
+	}
+
+	testCases := []testCase{
+		{
+			name:          "no CA",
+			expectedError: true,
+		},
+		{
+			name:     "no CA, explicitly insecure",
+			insecure: true,
+			expectedTLSConf: map[string]interface{}{
+				"insecureSkipVerify": true,
+			},
+		},
+		{
+			name: "secret CA",
+			ca:   SecretCA("exporter-tls"),
+			expectedTLSConf: map[string]interface{}{
+				"ca": map[string]interface{}{
+					"secret": map[string]interface{}{"name": "exporter-tls", "key": "ca.crt"},
+				},
+				"serverName": "exporter-metrics.tas.svc",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tcEp := ep
+			tcEp.CA = tc.ca
+			tcEp.InsecureSkipVerify = tc.insecure
+			mon, err := NewMonitoring(tcEp, MonitorServiceMonitor, "monitoring/prometheus-k8s")
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("NewMonitoring() succeeded without a CA")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewMonitoring() failed: %v", err)
+			}
+			endpoints, _, _ := unstructured.NestedSlice(mon.Monitor.Object, "spec", "endpoints")
+			got := endpoints[0].(map[string]interface{})["tlsConfig"]
+			if !reflect.DeepEqual(got, tc.expectedTLSConf) {
+				t.Errorf("tlsConfig got=%v expected=%v", got, tc.expectedTLSConf)
+			}
+		})
+	}
+}
+
+func TestNewMonitoringMalformedScraper(t *testing.T) {
+	for _, scraper := range []string{"", "prometheus-k8s", "monitoring/", "/prometheus-k8s"} {
+		_, err := NewMonitoring(MetricsEndpoint{Namespace: "tas", Name: "exporter"}, MonitorServiceMonitor, scraper)
+		if err == nil {
+			t.Errorf("scraper %q: expected error, got none", scraper)
+		}
+	}
+}
+
+func TestMonitoringNamespaceLabels(t *testing.T) {
+	if labels := MonitoringNamespaceLabels(platform.Kubernetes, MonitoringStackCluster); labels != nil {
+		t.Errorf("unexpected labels on kubernetes: %v", labels)
+	}
+	labels := MonitoringNamespaceLabels(platform.OpenShift, MonitoringStackCluster)
+	if labels["openshift.io/cluster-monitoring"] != "true" {
+		t.Errorf("unexpected labels for the cluster stack: %v", labels)
+	}
+	labels = MonitoringNamespaceLabels(platform.OpenShift, MonitoringStackUserWorkload)
+	if labels["openshift.io/user-monitoring"] != "true" || labels["openshift.io/cluster-monitoring"] != "false" {
+		t.Errorf("unexpected labels for the user workload stack: %v", labels)
+	}
+}
@@ -17,13 +17,17 @@
 package nfd
 
 import (
+	"fmt"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate"
 	nfdupdate "github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate/nfd"
 	rbacupdate "github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate/rbac"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
@@ -35,18 +39,26 @@ type Manifests struct {
 	CRTopologyUpdater  *rbacv1.ClusterRole
 	CRBTopologyUpdater *rbacv1.ClusterRoleBinding
 	DSTopologyUpdater  *appsv1.DaemonSet
+	// NPDefaultTopologyUpdater and NPApiServerTopologyUpdater are not rendered if the network policies are disabled
+	NPDefaultTopologyUpdater   *networkingv1.NetworkPolicy
+	NPApiServerTopologyUpdater *networkingv1.NetworkPolicy
+	// ImagePullSecret is rendered only if cloned from an existing secret
+	ImagePullSecret *corev1.Secret
 
 	plat platform.Platform
 }
 
 func (mf Manifests) Clone() Manifests {
 	ret := Manifests{
-		plat:               mf.plat,
-		Namespace:          mf.Namespace.DeepCopy(),
-		CRTopologyUpdater:  mf.CRTopologyUpdater.DeepCopy(),
-		CRBTopologyUpdater: mf.CRBTopologyUpdater.DeepCopy(),
-		DSTopologyUpdater:  mf.DSTopologyUpdater.DeepCopy(),
-		SATopologyUpdater:  mf.SATopologyUpdater.DeepCopy(),
+		plat:                       mf.plat,
+		Namespace:                  mf.Namespace.DeepCopy(),
+		CRTopologyUpdater:          mf.CRTopologyUpdater.DeepCopy(),
+		CRBTopologyUpdater:         mf.CRBTopologyUpdater.DeepCopy(),
+		DSTopologyUpdater:          mf.DSTopologyUpdater.DeepCopy(),
+		SATopologyUpdater:          mf.SATopologyUpdater.DeepCopy(),
+		ImagePullSecret:            mf.ImagePullSecret.DeepCopy(),
+		NPDefaultTopologyUpdater:   mf.NPDefaultTopologyUpdater.DeepCopy(),
+		NPApiServerTopologyUpdater: mf.NPApiServerTopologyUpdater.DeepCopy(),
 	}
 
 	return ret
@@ -63,13 +75,27 @@ func (mf Manifests) Render(opts options.UpdaterDaemon) (Manifests, error) {
 
 	ret.DSTopologyUpdater.Spec.Template.Spec.ServiceAccountName = mf.SATopologyUpdater.Name
 
-	nfdupdate.UpdaterDaemonSet(ret.DSTopologyUpdater, opts.DaemonSet)
+	if err := nfdupdate.UpdaterDaemonSet(ret.DSTopologyUpdater, opts.DaemonSet); err != nil {
+		return ret, fmt.Errorf("daemonset %q: %w", ret.DSTopologyUpdater.Name, err)
+	}
+	if opts.DaemonSet.ImagePullSecret != "" && opts.ImagePullSecretSource != nil {
+		ret.ImagePullSecret = manifests.CreateImagePullSecret(ret.DSTopologyUpdater.Namespace, opts.DaemonSet.ImagePullSecret, opts.ImagePullSecretSource)
+	}
+
+	if opts.NetworkPolicies.Disabled {
+		ret.NPDefaultTopologyUpdater = nil
+		ret.NPApiServerTopologyUpdater = nil
+	} else {
+		ret.NPDefaultTopologyUpdater.Namespace = ret.DSTopologyUpdater.Namespace
+		ret.NPApiServerTopologyUpdater.Namespace = ret.DSTopologyUpdater.Namespace
+		objectupdate.APIServerNetworkPolicy(ret.NPApiServerTopologyUpdater, opts.NetworkPolicies.APIServerAddresses, opts.NetworkPolicies.APIServerPorts)
+	}
 
 	return ret, nil
 }
 
 func (mf Manifests) ToObjects() []client.Object {
-	return []client.Object{
+	objs := []client.Object{
 		mf.Namespace,
 		// topology-updater objects
 		mf.SATopologyUpdater,
@@ -77,6 +103,13 @@ func (mf Manifests) ToObjects() []client.Object {
 		mf.CRBTopologyUpdater,
 		mf.DSTopologyUpdater,
 	}
+	if mf.NPDefaultTopologyUpdater != nil {
+		objs = append(objs, mf.NPDefaultTopologyUpdater, mf.NPApiServerTopologyUpdater)
+	}
+	if mf.ImagePullSecret != nil {
+		objs = append(objs, mf.ImagePullSecret)
+	}
+	return objs
 }
 
 func New(plat platform.Platform) Manifests {
@@ -112,6 +145,14 @@ func NewWithOptions(opts options.Render) (Manifests, error) {
 	if err != nil {
 		return mf, err
 	}
+	mf.NPDefaultTopologyUpdater, err = manifests.NetworkPolicy(manifests.ComponentNodeFeatureDiscovery, manifests.SubComponentNodeFeatureDiscoveryTopologyUpdater, manifests.DefaultNetworkPolicy, opts.Namespace)
+	if err != nil {
+		return mf, err
+	}
+	mf.NPApiServerTopologyUpdater, err = manifests.NetworkPolicy(manifests.ComponentNodeFeatureDiscovery, manifests.SubComponentNodeFeatureDiscoveryTopologyUpdater, manifests.APIServerNetworkPolicy, opts.Namespace)
+	if err != nil {
+		return mf, err
+	}
 
 	return mf, nil
 }
@@ -20,6 +20,8 @@ import (
 	"reflect"
 	"testing"
 
+	networkingv1 "k8s.io/api/networking/v1"
+
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
@@ -132,3 +134,50 @@ func TestRender(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderNetworkPolicies(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform: platform.Kubernetes,
+	})
+	if err != nil {
+		t.Fatalf("NewWithOptions() failed: %v", err)
+	}
+
+	uMf, err := mf.Render(options.UpdaterDaemon{
+		NetworkPolicies: options.NetworkPolicies{
+			APIServerPorts: []int32{443},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	if uMf.NPDefaultTopologyUpdater == nil || uMf.NPApiServerTopologyUpdater == nil {
+		t.Fatalf("network policies not rendered")
+	}
+	for _, np := range []*networkingv1.NetworkPolicy{uMf.NPDefaultTopologyUpdater, uMf.NPApiServerTopologyUpdater} {
+		if np.Namespace != uMf.DSTopologyUpdater.Namespace {
+			t.Errorf("network policy %q in namespace %q, expected %q", np.Name, np.Namespace, uMf.DSTopologyUpdater.Namespace)
+		}
+		if !reflect.DeepEqual(np.Spec.PodSelector.MatchLabels, uMf.DSTopologyUpdater.Spec.Template.Labels) {
+			t.Errorf("network policy %q does not select the topology updater pods", np.Name)
+		}
+	}
+	ports := uMf.NPApiServerTopologyUpdater.Spec.Egress[0].Ports
+	if len(ports) != 1 || ports[0].Port.IntValue() != 443 {
+		t.Errorf("unexpected API server ports: %v", ports)
+	}
+
+	uMf, err = mf.Render(options.UpdaterDaemon{
+		NetworkPolicies: options.NetworkPolicies{
+			Disabled: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	for _, obj := range uMf.ToObjects() {
+		if _, ok := obj.(*networkingv1.NetworkPolicy); ok {
+			t.Errorf("unexpected network policy %q with the network policies disabled", obj.GetName())
+		}
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+const (
+	// PodSecurityLabelEnforce, PodSecurityLabelAudit and PodSecurityLabelWarn set the Pod Security Admission levels of a namespace
+	PodSecurityLabelEnforce = "pod-security.kubernetes.io/enforce"
+	PodSecurityLabelAudit   = "pod-security.kubernetes.io/audit"
+	PodSecurityLabelWarn    = "pod-security.kubernetes.io/warn"
+)
+
+const (
+	PodSecurityPrivileged = "privileged"
+	PodSecurityBaseline   = "baseline"
+	PodSecurityRestricted = "restricted"
+)
+
+// PodSecurityLevel returns the minimum Pod Security Standard level the pods of the component need.
+// The updaters mount host paths, the scheduler pods don't set the restricted security context.
+func PodSecurityLevel(component string) string {
+	switch component {
+	case ComponentResourceTopologyExporter, ComponentNodeFeatureDiscovery:
+		return PodSecurityPrivileged
+	case ComponentSchedulerPlugin:
+		return PodSecurityBaseline
+	default:
+		return PodSecurityRestricted
+	}
+}
+
+// PodSecurityLabels returns the namespace labels enforcing, auditing and warning about the level
+func PodSecurityLabels(level string) map[string]string {
+	return map[string]string{
+		PodSecurityLabelEnforce: level,
+		PodSecurityLabelAudit:   level,
+		PodSecurityLabelWarn:    level,
+	}
+}
+
+// PodSecurityAllows tells if the enforced level admits the pods needing the required level.
+// Unknown levels are handled as restricted, like Pod Security Admission does.
+func PodSecurityAllows(enforced, required string) bool {
+	return podSecurityRank(enforced) >= podSecurityRank(required)
+}
+
+func podSecurityRank(level string) int {
+	switch level {
+	case PodSecurityPrivileged:
+		return 2
+	case PodSecurityBaseline:
+		return 1
+	default:
+		return 0
+	}
+}
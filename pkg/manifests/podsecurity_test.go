@@ -0,0 +1,61 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package manifests
+
+import (
+	"testing"
+)
+
+func TestPodSecurityAllows(t *testing.T) {
+	type testCase struct {
+		enforced string
+		required string
+		expected bool
+	}
+
+	testCases := []testCase{
+		{enforced: PodSecurityPrivileged, required: PodSecurityPrivileged, expected: true},
+		{enforced: PodSecurityPrivileged, required: PodSecurityRestricted, expected: true},
+		{enforced: PodSecurityBaseline, required: PodSecurityPrivileged, expected: false},
+		{enforced: PodSecurityBaseline, required: PodSecurityBaseline, expected: true},
+		{enforced: PodSecurityRestricted, required: PodSecurityBaseline, expected: false},
+		{enforced: "unknown", required: PodSecurityBaseline, expected: false},
+		{enforced: "unknown", required: PodSecurityRestricted, expected: true},
+	}
+
+	for _, tc := range testCases {
+		got := PodSecurityAllows(tc.enforced, tc.required)
+		if got != tc.expected {
+			t.Errorf("PodSecurityAllows(%q, %q)=%v expected %v", tc.enforced, tc.required, got, tc.expected)
+		}
+	}
+}
+
+func TestNamespacePodSecurityLabels(t *testing.T) {
+	for _, component := range []string{ComponentResourceTopologyExporter, ComponentNodeFeatureDiscovery, ComponentSchedulerPlugin} {
+		ns, err := Namespace(component)
+		if err != nil {
+			t.Fatalf("Namespace(%q) failed: %v", component, err)
+		}
+		level := PodSecurityLevel(component)
+		for key, value := range PodSecurityLabels(level) {
+			if ns.Labels[key] != value {
+				t.Errorf("namespace %q: label %q=%q expected %q", ns.Name, key, ns.Labels[key], value)
+			}
+		}
+	}
+}
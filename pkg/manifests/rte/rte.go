@@ -17,6 +17,8 @@
 package rte
 
 import (
+	"fmt"
+
 	selinuxassets "github.com/k8stopologyawareschedwg/deployer/pkg/assets/selinux"
 	machineconfigv1 "github.com/openshift/api/machineconfiguration/v1"
 	securityv1 "github.com/openshift/api/security/v1"
@@ -26,9 +28,11 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate"
 	ocpupdate "github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate/ocp"
 	rbacupdate "github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate/rbac"
 	rteupdate "github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate/rte"
@@ -37,6 +41,13 @@ import (
 
 const (
 	configDataField = "config.yaml"
+	// nodePoolConfigDataField is the key HyperShift expects in the ConfigMaps referenced by NodePool.spec.config
+	nodePoolConfigDataField = "config"
+)
+
+const (
+	// DefaultNodePoolNamespace is the default namespace of the HostedCluster and NodePool objects on the management cluster
+	DefaultNodePoolNamespace = "clusters"
 )
 
 type Manifests struct {
@@ -50,12 +61,22 @@ type Manifests struct {
 	DefaultNetworkPolicy       *networkingv1.NetworkPolicy
 	APIServerNetworkPolicy     *networkingv1.NetworkPolicy
 	MetricsServerNetworkPolicy *networkingv1.NetworkPolicy
+	// ImagePullSecret is rendered only if cloned from an existing secret
+	ImagePullSecret *corev1.Secret
+	// Monitoring is rendered only if the metrics are scraped
+	Monitoring *manifests.Monitoring
+	// MetricsService is rendered only to get the metrics serving certificate from the service CA operator without monitors
+	MetricsService *corev1.Service
 
 	// OpenShift related components
 	MachineConfig               *machineconfigv1.MachineConfig
 	SecurityContextConstraint   *securityv1.SecurityContextConstraints
 	SecurityContextConstraintV2 *securityv1.SecurityContextConstraints
 
+	// HyperShift related components
+	// NodePoolConfigMap wraps the MachineConfig and must be created on the management cluster
+	NodePoolConfigMap *corev1.ConfigMap
+
 	// internal fields
 	plat platform.Platform
 }
@@ -74,6 +95,9 @@ func (mf Manifests) Clone() Manifests {
 		DefaultNetworkPolicy:       mf.DefaultNetworkPolicy.DeepCopy(),
 		APIServerNetworkPolicy:     mf.APIServerNetworkPolicy.DeepCopy(),
 		MetricsServerNetworkPolicy: mf.MetricsServerNetworkPolicy.DeepCopy(),
+		ImagePullSecret:            mf.ImagePullSecret.DeepCopy(),
+		Monitoring:                 mf.Monitoring.DeepCopy(),
+		MetricsService:             mf.MetricsService.DeepCopy(),
 	}
 
 	if mf.plat == platform.OpenShift || mf.plat == platform.HyperShift {
@@ -83,6 +107,9 @@ func (mf Manifests) Clone() Manifests {
 		}
 		ret.SecurityContextConstraint = mf.SecurityContextConstraint.DeepCopy()
 		ret.SecurityContextConstraintV2 = mf.SecurityContextConstraintV2.DeepCopy()
+		if mf.NodePoolConfigMap != nil {
+			ret.NodePoolConfigMap = mf.NodePoolConfigMap.DeepCopy()
+		}
 	}
 
 	return ret
@@ -121,7 +148,62 @@ func (mf Manifests) Render(opts options.UpdaterDaemon) (Manifests, error) {
 	if ret.ConfigMap != nil {
 		rteConfigMapName = ret.ConfigMap.Name
 	}
-	rteupdate.DaemonSet(ret.DaemonSet, mf.plat, rteConfigMapName, opts.DaemonSet)
+	dsOpts := opts.DaemonSet
+	if dsOpts.MetricsTLS.Mode == options.MetricsTLSServiceCA {
+		if mf.plat != platform.OpenShift && mf.plat != platform.HyperShift {
+			return ret, fmt.Errorf("the service CA metrics certificate requires OpenShift, use a secret on %s", mf.plat)
+		}
+		if dsOpts.MetricsTLS.SecretName == "" {
+			dsOpts.MetricsTLS.SecretName = ret.DaemonSet.Name + "-metrics-cert"
+		}
+	}
+	if err := rteupdate.DaemonSet(ret.DaemonSet, mf.plat, rteConfigMapName, dsOpts); err != nil {
+		return ret, fmt.Errorf("daemonset %q: %w", ret.DaemonSet.Name, err)
+	}
+	if opts.DaemonSet.ImagePullSecret != "" && opts.ImagePullSecretSource != nil {
+		ret.ImagePullSecret = manifests.CreateImagePullSecret(ret.DaemonSet.Namespace, opts.DaemonSet.ImagePullSecret, opts.ImagePullSecretSource)
+	}
+
+	metricsPort := rteupdate.MetricsPortFromOptions(dsOpts)
+	if opts.NetworkPolicies.Disabled {
+		ret.DefaultNetworkPolicy = nil
+		ret.APIServerNetworkPolicy = nil
+		ret.MetricsServerNetworkPolicy = nil
+	} else {
+		objectupdate.APIServerNetworkPolicy(ret.APIServerNetworkPolicy, opts.NetworkPolicies.APIServerAddresses, opts.NetworkPolicies.APIServerPorts)
+		rteupdate.MetricsNetworkPolicy(ret.MetricsServerNetworkPolicy, metricsPort)
+	}
+	metricsEp := manifests.MetricsEndpoint{
+		Namespace: ret.DaemonSet.Namespace,
+		Name:      ret.DaemonSet.Name,
+		Selector:  ret.DaemonSet.Spec.Template.Labels,
+		Port:      int32(metricsPort),
+		PortName:  rteupdate.MetricsPortName,
+		TLS:       dsOpts.MetricsTLS.Enabled(),
+	}
+	if dsOpts.MetricsTLS.Mode == options.MetricsTLSServiceCA {
+		metricsEp.ServingCertSecret = dsOpts.MetricsTLS.SecretName
+	}
+	if opts.Metrics.InsecureSkipVerify {
+		metricsEp.InsecureSkipVerify = true
+	} else if dsOpts.MetricsTLS.Mode == options.MetricsTLSServiceCA {
+		metricsEp.CA = manifests.ServiceCA()
+	} else if dsOpts.MetricsTLS.Mode == options.MetricsTLSSecret {
+		metricsEp.CA = manifests.SecretCA(dsOpts.MetricsTLS.SecretName)
+	}
+	if opts.Metrics.Monitor != "" {
+		scraper := opts.Metrics.Scraper
+		if scraper == "" {
+			scraper = manifests.DefaultMetricsScraper(mf.plat, opts.Metrics.Stack)
+		}
+		mon, err := manifests.NewMonitoring(metricsEp, opts.Metrics.Monitor, scraper)
+		if err != nil {
+			return ret, err
+		}
+		ret.Monitoring = mon
+	} else if metricsEp.ServingCertSecret != "" {
+		ret.MetricsService = manifests.NewMetricsService(metricsEp)
+	}
 
 	if mf.plat == platform.OpenShift || mf.plat == platform.HyperShift {
 		if mf.MachineConfig != nil {
@@ -132,6 +214,15 @@ func (mf Manifests) Render(opts options.UpdaterDaemon) (Manifests, error) {
 				ret.MachineConfig.Labels = opts.MachineConfigPoolSelector.MatchLabels
 			}
 			// the MachineConfig installs this custom policy which is obsolete starting from OCP v4.18
+			if mf.plat == platform.HyperShift {
+				// hosted clusters have no MachineConfig API: the node configuration flows through the NodePool
+				cm, err := CreateNodePoolConfigMap(opts.NodePoolNamespace, ret.MachineConfig)
+				if err != nil {
+					return ret, err
+				}
+				ret.NodePoolConfigMap = cm
+				ret.MachineConfig = nil
+			}
 		}
 		ocpupdate.SecurityContextConstraint(ret.SecurityContextConstraint, ret.ServiceAccount)
 		ocpupdate.SecurityContextConstraint(ret.SecurityContextConstraintV2, ret.ServiceAccount)
@@ -172,6 +263,36 @@ func CreateConfigMap(namespace, name, configData string) *corev1.ConfigMap {
 	return cm
 }
 
+// CreateNodePoolConfigMap wraps the MachineConfig in a ConfigMap which HyperShift NodePools can reference in spec.config
+func CreateNodePoolConfigMap(namespace string, mc *machineconfigv1.MachineConfig) (*corev1.ConfigMap, error) {
+	if namespace == "" {
+		namespace = DefaultNodePoolNamespace
+	}
+	obj := mc.DeepCopy()
+	obj.TypeMeta = metav1.TypeMeta{
+		Kind:       "MachineConfig",
+		APIVersion: machineconfigv1.GroupVersion.String(),
+	}
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mc.Name,
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			nodePoolConfigDataField: string(data),
+		},
+	}
+	return cm, nil
+}
+
 func (mf Manifests) ToObjects() []client.Object {
 	var objs []client.Object
 
@@ -179,10 +300,18 @@ func (mf Manifests) ToObjects() []client.Object {
 		objs = append(objs, mf.ConfigMap)
 	}
 
+	if mf.ImagePullSecret != nil {
+		objs = append(objs, mf.ImagePullSecret)
+	}
+
 	if mf.MachineConfig != nil {
 		objs = append(objs, mf.MachineConfig)
 	}
 
+	if mf.NodePoolConfigMap != nil {
+		objs = append(objs, mf.NodePoolConfigMap)
+	}
+
 	if mf.SecurityContextConstraint != nil {
 		objs = append(objs, mf.SecurityContextConstraint)
 	}
@@ -190,17 +319,25 @@ func (mf Manifests) ToObjects() []client.Object {
 		objs = append(objs, mf.SecurityContextConstraintV2)
 	}
 
-	return append(objs,
+	objs = append(objs,
 		mf.Role,
 		mf.RoleBinding,
 		mf.ClusterRole,
 		mf.ClusterRoleBinding,
 		mf.DaemonSet,
 		mf.ServiceAccount,
-		mf.DefaultNetworkPolicy,
-		mf.APIServerNetworkPolicy,
-		mf.MetricsServerNetworkPolicy,
 	)
+	if mf.DefaultNetworkPolicy != nil {
+		objs = append(objs,
+			mf.DefaultNetworkPolicy,
+			mf.APIServerNetworkPolicy,
+			mf.MetricsServerNetworkPolicy,
+		)
+	}
+	if mf.MetricsService != nil {
+		objs = append(objs, mf.MetricsService)
+	}
+	return append(objs, mf.Monitoring.ToObjects()...)
 }
 
 func New(plat platform.Platform) Manifests {
@@ -215,7 +352,7 @@ func NewWithOptions(opts options.Render) (Manifests, error) {
 	var err error
 	mf := New(opts.Platform)
 
-	if opts.Platform == platform.OpenShift && opts.CustomSELinuxPolicy {
+	if (opts.Platform == platform.OpenShift || opts.Platform == platform.HyperShift) && opts.CustomSELinuxPolicy {
 		mf.MachineConfig, err = manifests.MachineConfig(manifests.ComponentResourceTopologyExporter, opts.PlatformVersion, opts.EnableCRIHooks)
 		if err != nil {
 			return mf, err
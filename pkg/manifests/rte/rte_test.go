@@ -18,9 +18,15 @@ package rte
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	rteupdate "github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate/rte"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
@@ -123,6 +129,119 @@ func TestRender(t *testing.T) {
 	}
 }
 
+func TestRenderPauseResourcesBelowDefaultRequests(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform: platform.Kubernetes,
+	})
+	if err != nil {
+		t.Fatalf("NewWithOptions() failed: %v", err)
+	}
+	// the shared pool container has its own overrides, and its own defaults
+	_, err = mf.Render(options.UpdaterDaemon{
+		DaemonSet: options.DaemonSet{
+			RTEResources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+			},
+			PauseResources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("8Mi"),
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("Render() succeeded with a shared pool limit lower than the default request")
+	}
+	if !strings.Contains(err.Error(), manifests.ContainerNameSharedPool) {
+		t.Errorf("error does not name the shared pool container: %v", err)
+	}
+}
+
+func TestRenderKubeletConfigFile(t *testing.T) {
+	type testCase struct {
+		name              string
+		flavor            platform.Flavor
+		kubeletConfigFile string
+		expectedArg       string
+		expectedHostPath  string
+	}
+
+	testCases := []testCase{
+		{
+			name:             "generic",
+			flavor:           platform.FlavorGeneric,
+			expectedArg:      "--kubelet-config-file=/host-var-lib-kubelet/config.yaml",
+			expectedHostPath: "/var/lib/kubelet",
+		},
+		{
+			name:             "k3s defaults",
+			flavor:           platform.FlavorK3s,
+			expectedArg:      "--kubelet-config-file=/host-var-lib-kubelet/00-k3s-defaults.conf",
+			expectedHostPath: "/var/lib/rancher/k3s/agent/etc/kubelet.conf.d",
+		},
+		{
+			name:              "k3s drop-in",
+			flavor:            platform.FlavorK3s,
+			kubeletConfigFile: "90-topology.conf",
+			expectedArg:       "--kubelet-config-file=/host-var-lib-kubelet/90-topology.conf",
+			expectedHostPath:  "/var/lib/rancher/k3s/agent/etc/kubelet.conf.d",
+		},
+		{
+			name:              "rke2 drop-in",
+			flavor:            platform.FlavorRKE2,
+			kubeletConfigFile: "90-topology.conf",
+			expectedArg:       "--kubelet-config-file=/host-var-lib-kubelet/90-topology.conf",
+			expectedHostPath:  "/var/lib/rancher/rke2/agent/etc/kubelet.conf.d",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mf, err := NewWithOptions(options.Render{
+				Platform: platform.Kubernetes,
+			})
+			if err != nil {
+				t.Fatalf("NewWithOptions() failed: %v", err)
+			}
+			uMf, err := mf.Render(options.UpdaterDaemon{
+				DaemonSet: options.DaemonSet{
+					Flavor:            tc.flavor,
+					KubeletConfigFile: tc.kubeletConfigFile,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Render() failed: %v", err)
+			}
+
+			podSpec := uMf.DaemonSet.Spec.Template.Spec
+			cnt := podSpec.Containers[0]
+			found := false
+			for _, arg := range cnt.Args {
+				if strings.HasPrefix(arg, "--kubelet-config-file") {
+					found = true
+					if arg != tc.expectedArg {
+						t.Errorf("kubelet config arg got=%q expected=%q", arg, tc.expectedArg)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("kubelet config arg missing: %v", cnt.Args)
+			}
+			hostPathFound := false
+			for _, vol := range podSpec.Volumes {
+				if vol.HostPath != nil && vol.HostPath.Path == tc.expectedHostPath {
+					hostPathFound = true
+				}
+			}
+			if !hostPathFound {
+				t.Errorf("kubelet config directory %q not mounted", tc.expectedHostPath)
+			}
+		})
+	}
+}
+
 func TestNewWithOptionsOpenShift(t *testing.T) {
 	type testCase struct {
 		name                    string
@@ -179,3 +298,130 @@ func TestNewWithOptionsOpenShift(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderHyperShift(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform:            platform.HyperShift,
+		PlatformVersion:     platform.Version("v4.14"),
+		Namespace:           "test",
+		CustomSELinuxPolicy: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	uMf, err := mf.Render(options.UpdaterDaemon{
+		NodePoolNamespace: "hosted",
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+
+	if uMf.MachineConfig != nil {
+		t.Errorf("machine config should not be generated for the HyperShift platform")
+	}
+	cm := uMf.NodePoolConfigMap
+	if cm == nil {
+		t.Fatalf("no NodePool config map is generated for the HyperShift platform")
+	}
+	if cm.Namespace != "hosted" {
+		t.Errorf("unexpected NodePool config map namespace: %q", cm.Namespace)
+	}
+	data := cm.Data[nodePoolConfigDataField]
+	if !strings.Contains(data, "kind: MachineConfig") || !strings.Contains(data, "apiVersion: machineconfiguration.openshift.io/v1") {
+		t.Errorf("NodePool config map does not contain a MachineConfig:\n%s", data)
+	}
+
+	found := false
+	for _, obj := range uMf.ToObjects() {
+		if obj == cm {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("NodePool config map not rendered")
+	}
+}
+
+func TestRenderMetricsEndpoint(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform:        platform.OpenShift,
+		PlatformVersion: platform.Version("v4.14"),
+		Namespace:       "test",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	uMf, err := mf.Render(options.UpdaterDaemon{
+		DaemonSet: options.DaemonSet{
+			MetricsPort: 9443,
+			MetricsTLS:  options.MetricsTLS{Mode: options.MetricsTLSServiceCA},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+
+	ports := uMf.MetricsServerNetworkPolicy.Spec.Ingress[0].Ports
+	if len(ports) != 1 || ports[0].Port.IntValue() != 9443 {
+		t.Errorf("metrics network policy out of sync: %v", ports)
+	}
+	svc := uMf.MetricsService
+	if svc == nil {
+		t.Fatalf("metrics service not rendered for the service CA certificate")
+	}
+	if svc.Spec.Ports[0].Port != 9443 {
+		t.Errorf("metrics service port out of sync: %d", svc.Spec.Ports[0].Port)
+	}
+	secretName := svc.Annotations["service.beta.openshift.io/serving-cert-secret-name"]
+	if secretName == "" {
+		t.Fatalf("metrics service does not request a serving certificate")
+	}
+	found := false
+	for _, vol := range uMf.DaemonSet.Spec.Template.Spec.Volumes {
+		if vol.Secret != nil && vol.Secret.SecretName == secretName {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("serving certificate secret %q not mounted", secretName)
+	}
+
+	uMf, err = mf.Render(options.UpdaterDaemon{
+		DaemonSet: options.DaemonSet{
+			MetricsTLS: options.MetricsTLS{Mode: options.MetricsTLSServiceCA},
+		},
+		Metrics: options.Metrics{
+			Monitor: manifests.MonitorPodMonitor,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	if uMf.MetricsService != nil {
+		t.Errorf("metrics service rendered twice")
+	}
+	if uMf.Monitoring == nil || uMf.Monitoring.Service == nil {
+		t.Fatalf("service monitor not rendered for the service CA certificate")
+	}
+	if uMf.Monitoring.Service.Spec.Ports[0].Port != rteupdate.DefaultMetricsPort {
+		t.Errorf("monitoring service port out of sync: %d", uMf.Monitoring.Service.Spec.Ports[0].Port)
+	}
+
+	mfK8s, err := NewWithOptions(options.Render{
+		Platform:  platform.Kubernetes,
+		Namespace: "test",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = mfK8s.Render(options.UpdaterDaemon{
+		DaemonSet: options.DaemonSet{
+			MetricsTLS: options.MetricsTLS{Mode: options.MetricsTLSServiceCA},
+		},
+	})
+	if err == nil {
+		t.Errorf("expected error for the service CA certificate on kubernetes, got none")
+	}
+}
@@ -19,6 +19,7 @@ package sched
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"strings"
 	"time"
 
@@ -27,14 +28,19 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate"
 	rbacupdate "github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate/rbac"
 	schedupdate "github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
@@ -46,6 +52,11 @@ const (
 	DefaultVerbose             = 4
 	DefaultCtrlPlaneAffinity   = true
 	DefaultLeaderElectResource = manifests.LeaderElectionDefaultNamespace + "/" + manifests.LeaderElectionDefaultName
+	// DefaultReplicasHosted is used when the replicas are autodetected but the control plane nodes are not visible,
+	// like on HyperShift hosted clusters or managed cloud offerings.
+	DefaultReplicasHosted = 2
+	// DefaultPriorityClassName is used in high availability mode unless a priority class is given
+	DefaultPriorityClassName = "system-cluster-critical"
 )
 
 const (
@@ -64,6 +75,8 @@ type Manifests struct {
 	DPController          *appsv1.Deployment
 	NPDefaultController   *networkingv1.NetworkPolicy
 	NPApiServerController *networkingv1.NetworkPolicy
+	// PDBController is rendered only in high availability mode
+	PDBController *policyv1.PodDisruptionBudget
 	// scheduler proper
 	SAScheduler          *corev1.ServiceAccount
 	CRScheduler          *rbacv1.ClusterRole
@@ -75,6 +88,14 @@ type Manifests struct {
 	ConfigMap            *corev1.ConfigMap
 	NPDefaultScheduler   *networkingv1.NetworkPolicy
 	NPApiServerScheduler *networkingv1.NetworkPolicy
+	// PDBScheduler is rendered only in high availability mode
+	PDBScheduler *policyv1.PodDisruptionBudget
+	// ImagePullSecret is rendered only if cloned from an existing secret
+	ImagePullSecret *corev1.Secret
+	// NPMetricsScheduler and Monitoring are rendered only if the metrics are scraped.
+	// None of the network policies are rendered if they are disabled.
+	NPMetricsScheduler *networkingv1.NetworkPolicy
+	Monitoring         *manifests.Monitoring
 	// internal fields
 	plat platform.Platform
 }
@@ -92,6 +113,7 @@ func (mf Manifests) Clone() Manifests {
 		RBController:          mf.RBController.DeepCopy(),
 		NPDefaultController:   mf.NPDefaultController.DeepCopy(),
 		NPApiServerController: mf.NPApiServerController.DeepCopy(),
+		PDBController:         mf.PDBController.DeepCopy(),
 		SAScheduler:           mf.SAScheduler.DeepCopy(),
 		CRScheduler:           mf.CRScheduler.DeepCopy(),
 		RSchedulerElect:       mf.RSchedulerElect.DeepCopy(),
@@ -102,6 +124,10 @@ func (mf Manifests) Clone() Manifests {
 		ConfigMap:             mf.ConfigMap.DeepCopy(),
 		NPDefaultScheduler:    mf.NPDefaultScheduler.DeepCopy(),
 		NPApiServerScheduler:  mf.NPApiServerScheduler.DeepCopy(),
+		PDBScheduler:          mf.PDBScheduler.DeepCopy(),
+		ImagePullSecret:       mf.ImagePullSecret.DeepCopy(),
+		NPMetricsScheduler:    mf.NPMetricsScheduler.DeepCopy(),
+		Monitoring:            mf.Monitoring.DeepCopy(),
 	}
 }
 
@@ -111,10 +137,14 @@ func (mf Manifests) Render(logger logr.Logger, opts options.Scheduler) (Manifest
 	if replicas <= 0 {
 		return ret, fmt.Errorf("non-positive replicas: %d", replicas)
 	}
+	if opts.HighAvailability && replicas < 2 {
+		return ret, fmt.Errorf("high availability requires at least 2 replicas, got %d", replicas)
+	}
 	ret.DPScheduler.Spec.Replicas = newInt32(replicas)
 	ret.DPController.Spec.Replicas = newInt32(replicas)
 
 	params := manifests.ConfigParams{
+		APIVersion:  schedulerConfigAPIVersion(logger, opts.Images.OrGet().SchedulerPluginScheduler),
 		ProfileName: opts.ProfileName,
 		Cache:       manifests.NewConfigCacheParams(),
 	}
@@ -125,6 +155,8 @@ func (mf Manifests) Render(logger logr.Logger, opts options.Scheduler) (Manifest
 	}
 	if ok {
 		params.LeaderElection = &leap
+	} else if opts.LeaseDuration > 0 || opts.RenewDeadline > 0 || opts.RetryPeriod > 0 {
+		logger.Info("leader election disabled, lease durations ignored")
 	}
 
 	if len(opts.CacheParamsConfigData) > 0 {
@@ -150,8 +182,35 @@ func (mf Manifests) Render(logger logr.Logger, opts options.Scheduler) (Manifest
 		return ret, err
 	}
 
-	schedupdate.SchedulerDeployment(ret.DPScheduler, opts.PullIfNotPresent, opts.CtrlPlaneAffinity, opts.Verbose)
-	schedupdate.ControllerDeployment(ret.DPController, opts.PullIfNotPresent, opts.CtrlPlaneAffinity)
+	ctrlPlaneAffinity := opts.CtrlPlaneAffinity
+	if ctrlPlaneAffinity && !opts.Flavor.Defaults().CtrlPlaneAffinity {
+		logger.Info("control plane affinity not supported, ignored", "flavor", opts.Flavor)
+		ctrlPlaneAffinity = false
+	}
+	if ctrlPlaneAffinity && mf.plat == platform.HyperShift {
+		// hosted clusters have no control plane nodes to run on
+		logger.Info("control plane affinity not supported, ignored", "platform", mf.plat)
+		ctrlPlaneAffinity = false
+	}
+	schedupdate.SchedulerDeployment(ret.DPScheduler, opts.Images, opts.PullIfNotPresent, ctrlPlaneAffinity, opts.Verbose)
+	schedupdate.ControllerDeployment(ret.DPController, opts.Images, opts.PullIfNotPresent, ctrlPlaneAffinity)
+	if err := objectupdate.SetContainerResources(&ret.DPScheduler.Spec.Template.Spec.Containers[0], opts.SchedulerResources); err != nil {
+		return ret, fmt.Errorf("deployment %q: %w", ret.DPScheduler.Name, err)
+	}
+	if err := objectupdate.SetContainerResources(&ret.DPController.Spec.Template.Spec.Containers[0], opts.ControllerResources); err != nil {
+		return ret, fmt.Errorf("deployment %q: %w", ret.DPController.Name, err)
+	}
+	priorityClassName := opts.PriorityClassName
+	if opts.HighAvailability {
+		if priorityClassName == "" {
+			priorityClassName = DefaultPriorityClassName
+		}
+		schedupdate.HighAvailability(ret.DPScheduler, priorityClassName)
+		schedupdate.HighAvailability(ret.DPController, priorityClassName)
+	} else if priorityClassName != "" {
+		ret.DPScheduler.Spec.Template.Spec.PriorityClassName = priorityClassName
+		ret.DPController.Spec.Template.Spec.PriorityClassName = priorityClassName
+	}
 	if opts.Namespace != "" {
 		ret.Namespace.Name = opts.Namespace
 	} else if mf.plat == platform.OpenShift || mf.plat == platform.HyperShift {
@@ -177,18 +236,87 @@ func (mf Manifests) Render(logger logr.Logger, opts options.Scheduler) (Manifest
 	ret.NPDefaultController.Namespace = ret.Namespace.Name
 	ret.NPApiServerController.Namespace = ret.Namespace.Name
 
+	if opts.HighAvailability {
+		ret.PDBScheduler = newPodDisruptionBudget(ret.DPScheduler)
+		ret.PDBController = newPodDisruptionBudget(ret.DPController)
+	}
+
+	objectupdate.SetPodImagePullSecret(&ret.DPScheduler.Spec.Template.Spec, opts.ImagePullSecret)
+	objectupdate.SetPodImagePullSecret(&ret.DPController.Spec.Template.Spec, opts.ImagePullSecret)
+	if opts.ImagePullSecret != "" && opts.ImagePullSecretSource != nil {
+		ret.ImagePullSecret = manifests.CreateImagePullSecret(ret.Namespace.Name, opts.ImagePullSecret, opts.ImagePullSecretSource)
+	}
+
+	if opts.Metrics.Monitor != "" {
+		err = renderMonitoring(logger, &ret, opts.Metrics)
+		if err != nil {
+			return ret, err
+		}
+	}
+
+	if opts.NetworkPolicies.Disabled {
+		ret.NPDefaultScheduler = nil
+		ret.NPApiServerScheduler = nil
+		ret.NPMetricsScheduler = nil
+		ret.NPDefaultController = nil
+		ret.NPApiServerController = nil
+	} else {
+		objectupdate.APIServerNetworkPolicy(ret.NPApiServerScheduler, opts.NetworkPolicies.APIServerAddresses, opts.NetworkPolicies.APIServerPorts)
+		objectupdate.APIServerNetworkPolicy(ret.NPApiServerController, opts.NetworkPolicies.APIServerAddresses, opts.NetworkPolicies.APIServerPorts)
+	}
+
 	return ret, nil
 }
 
+// renderMonitoring exposes the scheduler secure metrics endpoint to the monitoring stack
+func renderMonitoring(logger logr.Logger, mf *Manifests, opts options.Metrics) error {
+	var err error
+	if opts.Monitor == manifests.MonitorPodMonitor {
+		logger.Info("the scheduler metrics are scraped through a ServiceMonitor, to authenticate the scraper")
+	}
+	scraper := opts.Scraper
+	if scraper == "" {
+		scraper = manifests.DefaultMetricsScraper(mf.plat, opts.Stack)
+	}
+	ep := manifests.MetricsEndpoint{
+		Namespace: mf.Namespace.Name,
+		Name:      mf.DPScheduler.Name,
+		Selector:  mf.DPScheduler.Spec.Template.Labels,
+		Port:      schedupdate.SecurePort,
+		PortName:  schedupdate.SecurePortName,
+		Secure:    true,
+	}
+	if mf.plat == platform.OpenShift || mf.plat == platform.HyperShift {
+		// the scraper token must not be sent to an unverified endpoint: serve a certificate signed by the service CA
+		ep.ServingCertSecret = mf.DPScheduler.Name + "-metrics-cert"
+		ep.CA = manifests.ServiceCA()
+		schedupdate.ServingCertForContainer(mf.DPScheduler, ep.ServingCertSecret)
+	} else if opts.InsecureSkipVerify {
+		ep.InsecureSkipVerify = true
+	} else {
+		logger.Info("scheduler metrics monitor not rendered: no CA to verify the self-signed scheduler certificate, see --metrics-insecure-skip-verify", "platform", mf.plat)
+		return nil
+	}
+	schedupdate.SecurePortForContainer(mf.DPScheduler)
+	mf.NPMetricsScheduler, err = manifests.NetworkPolicy(manifests.ComponentSchedulerPlugin, manifests.SubComponentSchedulerPluginScheduler, manifests.MetricsServerNetworkPolicy, mf.Namespace.Name)
+	if err != nil {
+		return err
+	}
+	mf.Monitoring, err = manifests.NewMonitoring(ep, opts.Monitor, scraper)
+	if err != nil {
+		return err
+	}
+	objectupdate.SetObjectLabels(mf.Namespace, manifests.MonitoringNamespaceLabels(mf.plat, opts.Stack))
+	return nil
+}
+
 func (mf Manifests) ToObjects() []client.Object {
-	return []client.Object{
+	objs := []client.Object{
 		mf.Crd,
 		mf.Namespace,
 		mf.SAScheduler,
 		mf.CRScheduler,
 		mf.CRBScheduler,
-		mf.NPDefaultScheduler,
-		mf.NPApiServerScheduler,
 		mf.ConfigMap,
 		mf.RSchedulerElect,
 		mf.RBSchedulerAuth,
@@ -199,9 +327,28 @@ func (mf Manifests) ToObjects() []client.Object {
 		mf.CRBController,
 		mf.DPController,
 		mf.RBController,
-		mf.NPDefaultController,
-		mf.NPApiServerController,
 	}
+	if mf.NPDefaultScheduler != nil {
+		objs = append(objs,
+			mf.NPDefaultScheduler,
+			mf.NPApiServerScheduler,
+			mf.NPDefaultController,
+			mf.NPApiServerController,
+		)
+	}
+	if mf.PDBScheduler != nil {
+		objs = append(objs, mf.PDBScheduler)
+	}
+	if mf.PDBController != nil {
+		objs = append(objs, mf.PDBController)
+	}
+	if mf.ImagePullSecret != nil {
+		objs = append(objs, mf.ImagePullSecret)
+	}
+	if mf.NPMetricsScheduler != nil {
+		objs = append(objs, mf.NPMetricsScheduler)
+	}
+	return append(objs, mf.Monitoring.ToObjects()...)
 }
 
 func New(plat platform.Platform) Manifests {
@@ -309,6 +456,18 @@ func leaderElectionParamsFromOpts(opts options.Scheduler) (manifests.LeaderElect
 
 	manifests.SetDefaultsLeaderElection(&leap)
 	leap.LeaderElect = true
+	if opts.LeaseDuration > 0 {
+		leap.LeaseDuration = &metav1.Duration{Duration: opts.LeaseDuration}
+	}
+	if opts.RenewDeadline > 0 {
+		leap.RenewDeadline = &metav1.Duration{Duration: opts.RenewDeadline}
+	}
+	if opts.RetryPeriod > 0 {
+		leap.RetryPeriod = &metav1.Duration{Duration: opts.RetryPeriod}
+	}
+	if err := manifests.ValidateLeaderElectionDurations(leap); err != nil {
+		return leap, true, err
+	}
 
 	var err error
 	tokens := strings.Split(opts.LeaderElectionResource, "/")
@@ -329,6 +488,42 @@ func leaderElectionParamsFromOpts(opts options.Scheduler) (manifests.LeaderElect
 	return leap, true, err
 }
 
+// schedulerConfigAPIVersion picks the configuration apiVersion the scheduler image understands
+func schedulerConfigAPIVersion(logger logr.Logger, image string) string {
+	kubeVer, ok := images.SchedulerPluginKubernetesVersion(image)
+	if !ok {
+		logger.Info("cannot infer the kubernetes version of the scheduler image, using the GA configuration", "image", image)
+		return manifests.SchedulerConfigAPIVersionV1
+	}
+	apiVersion, err := manifests.SchedulerConfigAPIVersionForKubernetes(platform.Version(kubeVer))
+	if err != nil {
+		logger.Info("cannot parse the kubernetes version of the scheduler image, using the GA configuration", "image", image, "version", kubeVer, "error", err)
+		return manifests.SchedulerConfigAPIVersionV1
+	}
+	logger.V(3).Info("scheduler configuration", "image", image, "kubernetesVersion", kubeVer, "apiVersion", apiVersion)
+	return apiVersion
+}
+
+// newPodDisruptionBudget allows to disrupt one replica of the deployment at a time
+func newPodDisruptionBudget(dp *appsv1.Deployment) *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt32(1)
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: "policy/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dp.Name,
+			Namespace: dp.Namespace,
+			Labels:    maps.Clone(dp.Labels),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector:       dp.Spec.Selector.DeepCopy(),
+		},
+	}
+}
+
 func newInt32(value int32) *int32 {
 	return &value
 }
@@ -17,13 +17,24 @@
 package sched
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr/testr"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+	schedupdate "github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate/sched"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
@@ -180,6 +191,37 @@ func Test_leaderElectionParamsFromOpts(t *testing.T) {
 				ResourceName:      "bar",
 			},
 		},
+		{
+			name: "lease durations",
+			opts: options.Scheduler{
+				LeaderElection: true,
+				LeaseDuration:  30 * time.Second,
+				RetryPeriod:    5 * time.Second,
+			},
+			expectedOK: true,
+			expectedParams: manifests.LeaderElectionParams{
+				LeaderElect:       true,
+				ResourceName:      manifests.LeaderElectionDefaultName,
+				ResourceNamespace: manifests.LeaderElectionDefaultNamespace,
+				LeaseDuration:     &metav1.Duration{Duration: 30 * time.Second},
+				RetryPeriod:       &metav1.Duration{Duration: 5 * time.Second},
+			},
+		},
+		{
+			name: "lease duration shorter than the default renew deadline",
+			opts: options.Scheduler{
+				LeaderElection: true,
+				LeaseDuration:  5 * time.Second,
+			},
+			expectedOK: true,
+			expectedParams: manifests.LeaderElectionParams{
+				LeaderElect:       true,
+				ResourceName:      manifests.LeaderElectionDefaultName,
+				ResourceNamespace: manifests.LeaderElectionDefaultNamespace,
+				LeaseDuration:     &metav1.Duration{Duration: 5 * time.Second},
+			},
+			expectedError: errors.New("inconsistent durations"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -197,3 +239,469 @@ func Test_leaderElectionParamsFromOpts(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderCtrlPlaneAffinity(t *testing.T) {
+	type testCase struct {
+		name             string
+		plat             platform.Platform
+		flavor           platform.Flavor
+		expectedAffinity bool
+	}
+
+	testCases := []testCase{
+		{
+			name:             "kubernetes",
+			plat:             platform.Kubernetes,
+			expectedAffinity: true,
+		},
+		{
+			name:             "openshift",
+			plat:             platform.OpenShift,
+			expectedAffinity: true,
+		},
+		{
+			name: "hypershift",
+			plat: platform.HyperShift,
+		},
+		{
+			name:   "managed kubernetes",
+			plat:   platform.Kubernetes,
+			flavor: platform.FlavorEKS,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mf, err := NewWithOptions(options.Render{
+				Platform: tc.plat,
+			})
+			if err != nil {
+				t.Fatalf("NewWithOptions(platform=%s) failed: %v", tc.plat, err)
+			}
+
+			uMf, err := mf.Render(testr.New(t), options.Scheduler{
+				Replicas:          int32(DefaultReplicasHosted),
+				Flavor:            tc.flavor,
+				CtrlPlaneAffinity: true,
+			})
+			if err != nil {
+				t.Fatalf("Render() failed: %v", err)
+			}
+
+			for _, dp := range []*appsv1.Deployment{uMf.DPScheduler, uMf.DPController} {
+				got := dp.Spec.Template.Spec.Affinity != nil && dp.Spec.Template.Spec.Affinity.NodeAffinity != nil
+				if got != tc.expectedAffinity {
+					t.Errorf("deployment %q: control plane affinity got=%v expected=%v", dp.Name, got, tc.expectedAffinity)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderHighAvailability(t *testing.T) {
+	type testCase struct {
+		name                  string
+		opts                  options.Scheduler
+		expectedPDB           bool
+		expectedPriorityClass string
+		expectedError         bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "disabled",
+			opts: options.Scheduler{
+				Replicas: int32(3),
+			},
+		},
+		{
+			name: "priority class only",
+			opts: options.Scheduler{
+				Replicas:          int32(1),
+				PriorityClassName: "foo",
+			},
+			expectedPriorityClass: "foo",
+		},
+		{
+			name: "enabled",
+			opts: options.Scheduler{
+				Replicas:         int32(3),
+				HighAvailability: true,
+			},
+			expectedPDB:           true,
+			expectedPriorityClass: DefaultPriorityClassName,
+		},
+		{
+			name: "enabled with priority class",
+			opts: options.Scheduler{
+				Replicas:          int32(2),
+				HighAvailability:  true,
+				PriorityClassName: "foo",
+			},
+			expectedPDB:           true,
+			expectedPriorityClass: "foo",
+		},
+		{
+			name: "enabled with one replica",
+			opts: options.Scheduler{
+				Replicas:         int32(1),
+				HighAvailability: true,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mf, err := NewWithOptions(options.Render{
+				Platform: platform.Kubernetes,
+			})
+			if err != nil {
+				t.Fatalf("NewWithOptions() failed: %v", err)
+			}
+
+			uMf, err := mf.Render(testr.New(t), tc.opts)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("Render() succeeded unexpectedly")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render() failed: %v", err)
+			}
+
+			gotPDB := uMf.PDBScheduler != nil && uMf.PDBController != nil
+			if gotPDB != tc.expectedPDB {
+				t.Errorf("PodDisruptionBudgets got=%v expected=%v", gotPDB, tc.expectedPDB)
+			}
+
+			for _, dp := range []*appsv1.Deployment{uMf.DPScheduler, uMf.DPController} {
+				podSpec := dp.Spec.Template.Spec
+				if podSpec.PriorityClassName != tc.expectedPriorityClass {
+					t.Errorf("deployment %q: priority class got=%q expected=%q", dp.Name, podSpec.PriorityClassName, tc.expectedPriorityClass)
+				}
+				gotSpread := podSpec.Affinity != nil && podSpec.Affinity.PodAntiAffinity != nil && len(podSpec.TopologySpreadConstraints) > 0
+				if gotSpread != tc.opts.HighAvailability {
+					t.Errorf("deployment %q: replicas spread got=%v expected=%v", dp.Name, gotSpread, tc.opts.HighAvailability)
+				}
+			}
+
+			if !tc.expectedPDB {
+				return
+			}
+			for _, pdb := range []*policyv1.PodDisruptionBudget{uMf.PDBScheduler, uMf.PDBController} {
+				if pdb.Namespace != uMf.Namespace.Name {
+					t.Errorf("PDB %q: namespace got=%q expected=%q", pdb.Name, pdb.Namespace, uMf.Namespace.Name)
+				}
+			}
+			if !reflect.DeepEqual(uMf.PDBScheduler.Spec.Selector, uMf.DPScheduler.Spec.Selector) {
+				t.Errorf("scheduler PDB selector mismatch: %v", uMf.PDBScheduler.Spec.Selector)
+			}
+			if len(uMf.ToObjects()) != len(mf.ToObjects())+2 {
+				t.Errorf("PodDisruptionBudgets missing from the objects")
+			}
+		})
+	}
+}
+
+func TestRenderHighAvailabilityRollout(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform: platform.Kubernetes,
+	})
+	if err != nil {
+		t.Fatalf("NewWithOptions() failed: %v", err)
+	}
+
+	// as many replicas as control plane nodes: each node runs exactly one replica
+	uMf, err := mf.Render(testr.New(t), options.Scheduler{
+		Replicas:          int32(3),
+		CtrlPlaneAffinity: true,
+		HighAvailability:  true,
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+
+	for _, dp := range []*appsv1.Deployment{uMf.DPScheduler, uMf.DPController} {
+		podSpec := dp.Spec.Template.Spec
+		if podSpec.Affinity == nil || podSpec.Affinity.NodeAffinity == nil || podSpec.Affinity.PodAntiAffinity == nil {
+			t.Fatalf("deployment %q: missing control plane affinity or anti affinity: %+v", dp.Name, podSpec.Affinity)
+		}
+		ru := dp.Spec.Strategy.RollingUpdate
+		if dp.Spec.Strategy.Type != appsv1.RollingUpdateDeploymentStrategyType || ru == nil || ru.MaxSurge == nil || ru.MaxUnavailable == nil {
+			t.Fatalf("deployment %q: unexpected strategy: %+v", dp.Name, dp.Spec.Strategy)
+		}
+		if ru.MaxSurge.IntValue() != 0 || ru.MaxUnavailable.IntValue() != 1 {
+			t.Errorf("deployment %q: rollout got maxSurge=%s maxUnavailable=%s expected maxSurge=0 maxUnavailable=1", dp.Name, ru.MaxSurge.String(), ru.MaxUnavailable.String())
+		}
+	}
+}
+
+func TestRenderImagePullSecret(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform: platform.Kubernetes,
+	})
+	if err != nil {
+		t.Fatalf("NewWithOptions() failed: %v", err)
+	}
+
+	src := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "secrets", Name: "source"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte("{}"),
+		},
+	}
+	for _, source := range []*corev1.Secret{nil, src} {
+		uMf, err := mf.Render(testr.New(t), options.Scheduler{
+			Replicas:              int32(1),
+			ImagePullSecret:       "regcred",
+			ImagePullSecretSource: source,
+		})
+		if err != nil {
+			t.Fatalf("Render() failed: %v", err)
+		}
+
+		for _, dp := range []*appsv1.Deployment{uMf.DPScheduler, uMf.DPController} {
+			secrets := dp.Spec.Template.Spec.ImagePullSecrets
+			if len(secrets) != 1 || secrets[0].Name != "regcred" {
+				t.Errorf("deployment %q: unexpected pull secrets: %v", dp.Name, secrets)
+			}
+		}
+		if source == nil {
+			if uMf.ImagePullSecret != nil {
+				t.Errorf("unexpected pull secret created without a source")
+			}
+			continue
+		}
+		sec := uMf.ImagePullSecret
+		if sec == nil {
+			t.Fatalf("pull secret not created")
+		}
+		if sec.Namespace != uMf.Namespace.Name || sec.Name != "regcred" || sec.Type != src.Type {
+			t.Errorf("unexpected pull secret: %s/%s type=%q", sec.Namespace, sec.Name, sec.Type)
+		}
+		if len(uMf.ToObjects()) != len(mf.ToObjects())+1 {
+			t.Errorf("pull secret missing from the objects")
+		}
+	}
+}
+
+func TestRenderResources(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform: platform.Kubernetes,
+	})
+	if err != nil {
+		t.Fatalf("NewWithOptions() failed: %v", err)
+	}
+
+	uMf, err := mf.Render(testr.New(t), options.Scheduler{
+		Replicas: int32(1),
+		SchedulerResources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+
+	res := uMf.DPScheduler.Spec.Template.Spec.Containers[0].Resources
+	if res.Requests.Cpu().IsZero() || res.Requests.Memory().IsZero() {
+		t.Errorf("scheduler default requests lost: %v", res.Requests)
+	}
+	if got := res.Limits.Memory().String(); got != "1Gi" {
+		t.Errorf("scheduler memory limit got=%q expected=%q", got, "1Gi")
+	}
+	if got := res.Limits.Cpu().String(); got != "200m" {
+		t.Errorf("scheduler default cpu limit got=%q expected=%q", got, "200m")
+	}
+
+	ctrlRes := uMf.DPController.Spec.Template.Spec.Containers[0].Resources
+	if len(ctrlRes.Limits) != 0 || ctrlRes.Requests.Cpu().IsZero() {
+		t.Errorf("unexpected controller resources: %v", ctrlRes)
+	}
+	if got := mf.DPScheduler.Spec.Template.Spec.Containers[0].Resources.Limits.Memory().String(); got != "500Mi" {
+		t.Errorf("Render() mutated the source manifests: memory limit %q", got)
+	}
+}
+
+func TestRenderConfigAPIVersion(t *testing.T) {
+	type testCase struct {
+		name               string
+		image              string
+		expectedAPIVersion string
+	}
+
+	testCases := []testCase{
+		{
+			name:               "default image",
+			expectedAPIVersion: manifests.SchedulerConfigAPIVersionV1,
+		},
+		{
+			name:               "old image",
+			image:              "registry.k8s.io/scheduler-plugins/kube-scheduler:v0.24.9",
+			expectedAPIVersion: manifests.SchedulerConfigAPIVersionV1Beta3,
+		},
+		{
+			name:               "unknown image version",
+			image:              "quay.io/example/kube-scheduler:latest",
+			expectedAPIVersion: manifests.SchedulerConfigAPIVersionV1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.image != "" {
+				t.Setenv("TAS_SCHEDULER_PLUGIN_IMAGE", tc.image)
+			}
+			mf, err := NewWithOptions(options.Render{
+				Platform: platform.Kubernetes,
+			})
+			if err != nil {
+				t.Fatalf("NewWithOptions() failed: %v", err)
+			}
+			uMf, err := mf.Render(testr.New(t), options.Scheduler{
+				Replicas: int32(1),
+			})
+			if err != nil {
+				t.Fatalf("Render() failed: %v", err)
+			}
+			data := uMf.ConfigMap.Data[manifests.SchedulerConfigFileName]
+			if !strings.HasPrefix(data, "apiVersion: "+tc.expectedAPIVersion+"\n") {
+				t.Errorf("unexpected scheduler config, expected apiVersion %q:\n%s", tc.expectedAPIVersion, data)
+			}
+		})
+	}
+}
+
+func TestRenderImages(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform: platform.Kubernetes,
+	})
+	if err != nil {
+		t.Fatalf("NewWithOptions() failed: %v", err)
+	}
+
+	is, ok := images.SelectImageSet("1.26")
+	if !ok {
+		t.Fatalf("no image set for kubernetes 1.26")
+	}
+	imgs, err := is.Images(false)
+	if err != nil {
+		t.Fatalf("Images() failed: %v", err)
+	}
+	uMf, err := mf.Render(testr.New(t), options.Scheduler{
+		Replicas: int32(1),
+		Images:   imgs,
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	if got := uMf.DPScheduler.Spec.Template.Spec.Containers[0].Image; got != imgs.SchedulerPluginScheduler {
+		t.Errorf("scheduler image got=%q expected=%q", got, imgs.SchedulerPluginScheduler)
+	}
+	if got := uMf.DPController.Spec.Template.Spec.Containers[0].Image; got != imgs.SchedulerPluginController {
+		t.Errorf("controller image got=%q expected=%q", got, imgs.SchedulerPluginController)
+	}
+	data := uMf.ConfigMap.Data[manifests.SchedulerConfigFileName]
+	if !strings.HasPrefix(data, "apiVersion: "+manifests.SchedulerConfigAPIVersionV1+"\n") {
+		t.Errorf("unexpected scheduler config apiVersion:\n%s", data)
+	}
+}
+
+func TestRenderMonitoring(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform: platform.OpenShift,
+	})
+	if err != nil {
+		t.Fatalf("NewWithOptions() failed: %v", err)
+	}
+
+	uMf, err := mf.Render(testr.New(t), options.Scheduler{
+		Replicas: int32(1),
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	if uMf.Monitoring != nil || uMf.NPMetricsScheduler != nil {
+		t.Errorf("unexpected monitoring objects without a monitor")
+	}
+
+	uMf, err = mf.Render(testr.New(t), options.Scheduler{
+		Replicas: int32(1),
+		Metrics: options.Metrics{
+			Monitor: manifests.MonitorPodMonitor,
+			Stack:   manifests.MonitoringStackUserWorkload,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	if uMf.NPMetricsScheduler == nil || uMf.Monitoring == nil {
+		t.Fatalf("monitoring objects not rendered")
+	}
+	if kind := uMf.Monitoring.Monitor.GetKind(); kind != manifests.MonitorServiceMonitor {
+		t.Errorf("got monitor kind %q expected %q", kind, manifests.MonitorServiceMonitor)
+	}
+	if uMf.Namespace.Labels["openshift.io/user-monitoring"] != "true" {
+		t.Errorf("namespace not labeled for the user workload monitoring: %v", uMf.Namespace.Labels)
+	}
+	if mf.Namespace.Labels["openshift.io/user-monitoring"] != "" {
+		t.Errorf("render mutated the source namespace")
+	}
+	found := false
+	for _, port := range uMf.DPScheduler.Spec.Template.Spec.Containers[0].Ports {
+		if port.Name == schedupdate.SecurePortName && port.ContainerPort == schedupdate.SecurePort {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("secure metrics port not exposed by the scheduler container")
+	}
+	args := strings.Join(uMf.DPScheduler.Spec.Template.Spec.Containers[0].Args, " ")
+	if !strings.Contains(args, "--tls-cert-file=/etc/secrets/scheduler/tls.crt") || !strings.Contains(args, "--tls-private-key-file=/etc/secrets/scheduler/tls.key") {
+		t.Errorf("scheduler not serving the service CA signed certificate: %v", args)
+	}
+	if uMf.Monitoring.Service == nil || uMf.Monitoring.Service.Annotations["service.beta.openshift.io/serving-cert-secret-name"] != "topology-aware-scheduler-metrics-cert" {
+		t.Errorf("serving certificate not requested to the service CA: %v", uMf.Monitoring.Service)
+	}
+}
+
+func TestRenderMonitoringUnverifiable(t *testing.T) {
+	mf, err := NewWithOptions(options.Render{
+		Platform: platform.Kubernetes,
+	})
+	if err != nil {
+		t.Fatalf("NewWithOptions() failed: %v", err)
+	}
+
+	uMf, err := mf.Render(testr.New(t), options.Scheduler{
+		Replicas: int32(1),
+		Metrics: options.Metrics{
+			Monitor: manifests.MonitorServiceMonitor,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	if uMf.Monitoring != nil || uMf.NPMetricsScheduler != nil {
+		t.Errorf("monitor rendered without a CA to verify the scheduler certificate")
+	}
+
+	uMf, err = mf.Render(testr.New(t), options.Scheduler{
+		Replicas: int32(1),
+		Metrics: options.Metrics{
+			Monitor:            manifests.MonitorServiceMonitor,
+			InsecureSkipVerify: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	if uMf.Monitoring == nil {
+		t.Fatalf("monitor not rendered despite the explicit opt-in")
+	}
+}
@@ -22,8 +22,11 @@ import (
 
 	"sigs.k8s.io/yaml"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 )
 
 const (
@@ -54,9 +57,22 @@ const (
 	ScoringStrategyLeastAllocated     = "LeastAllocated"
 )
 
+const (
+	SchedulerConfigAPIVersionV1Beta3 = "kubescheduler.config.k8s.io/v1beta3"
+	SchedulerConfigAPIVersionV1      = "kubescheduler.config.k8s.io/v1"
+
+	// SchedulerConfigAPIVersionV1MinKubernetes is the first kubernetes version serving the GA scheduler configuration
+	SchedulerConfigAPIVersionV1MinKubernetes = "1.25"
+)
+
 const (
 	LeaderElectionDefaultName      = "nrtmatch-scheduler"
 	LeaderElectionDefaultNamespace = "tas-scheduler"
+
+	// the kube-scheduler defaults, used when the durations are not set
+	LeaderElectionDefaultLeaseDuration = 15 * time.Second
+	LeaderElectionDefaultRenewDeadline = 10 * time.Second
+	LeaderElectionDefaultRetryPeriod   = 2 * time.Second
 )
 
 func ValidateForeignPodsDetectMode(value string) error {
@@ -96,6 +112,29 @@ func ValidateCacheInformerMode(value string) error {
 	}
 }
 
+func ValidateSchedulerConfigAPIVersion(value string) error {
+	switch value {
+	case SchedulerConfigAPIVersionV1Beta3:
+		return nil
+	case SchedulerConfigAPIVersionV1:
+		return nil
+	default:
+		return fmt.Errorf("unsupported scheduler config apiVersion: %v", value)
+	}
+}
+
+// SchedulerConfigAPIVersionForKubernetes returns the scheduler configuration apiVersion to use with the given kubernetes version
+func SchedulerConfigAPIVersionForKubernetes(ver platform.Version) (string, error) {
+	ok, err := ver.AtLeastString(SchedulerConfigAPIVersionV1MinKubernetes)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return SchedulerConfigAPIVersionV1, nil
+	}
+	return SchedulerConfigAPIVersionV1Beta3, nil
+}
+
 type ConfigCacheParams struct {
 	ResyncPeriodSeconds   *int64  `json:"-"`
 	ResyncMethod          *string `json:"resyncMethod,omitempty"`
@@ -145,6 +184,35 @@ type LeaderElectionParams struct {
 	LeaderElect       bool   `json:"leaderElect"`
 	ResourceNamespace string `json:"resourceNamespace,omitempty"`
 	ResourceName      string `json:"resourceName,omitempty"`
+	// the lease durations are left to the scheduler defaults if nil
+	LeaseDuration *metav1.Duration `json:"leaseDuration,omitempty"`
+	RenewDeadline *metav1.Duration `json:"renewDeadline,omitempty"`
+	RetryPeriod   *metav1.Duration `json:"retryPeriod,omitempty"`
+}
+
+// ValidateLeaderElectionDurations checks the lease durations are consistent, using the scheduler defaults
+// for the unset ones: the lease duration must exceed the renew deadline, which must exceed the retry period.
+func ValidateLeaderElectionDurations(lep LeaderElectionParams) error {
+	leaseDuration := durationOrDefault(lep.LeaseDuration, LeaderElectionDefaultLeaseDuration)
+	renewDeadline := durationOrDefault(lep.RenewDeadline, LeaderElectionDefaultRenewDeadline)
+	retryPeriod := durationOrDefault(lep.RetryPeriod, LeaderElectionDefaultRetryPeriod)
+	if retryPeriod <= 0 {
+		return fmt.Errorf("non-positive leader election retry period: %v", retryPeriod)
+	}
+	if leaseDuration <= renewDeadline {
+		return fmt.Errorf("leader election lease duration %v must be greater than the renew deadline %v", leaseDuration, renewDeadline)
+	}
+	if renewDeadline <= retryPeriod {
+		return fmt.Errorf("leader election renew deadline %v must be greater than the retry period %v", renewDeadline, retryPeriod)
+	}
+	return nil
+}
+
+func durationOrDefault(d *metav1.Duration, defaultValue time.Duration) time.Duration {
+	if d == nil {
+		return defaultValue
+	}
+	return d.Duration
 }
 
 func SetDefaultsLeaderElection(lep *LeaderElectionParams) {
@@ -157,6 +225,9 @@ func SetDefaultsLeaderElection(lep *LeaderElectionParams) {
 }
 
 type ConfigParams struct {
+	// APIVersion of the rendered configuration. Empty means keep the apiVersion of the template.
+	// Like the leader election params, this is a global setting.
+	APIVersion string `json:"apiVersion,omitempty"`
 	// can't be empty, so no need for pointer
 	ProfileName     string                 `json:"profileName"`
 	Cache           *ConfigCacheParams     `json:"cache"`
@@ -173,6 +244,18 @@ func DecodeSchedulerProfilesFromData(data []byte) ([]ConfigParams, error) {
 		return params, nil
 	}
 
+	// both the GA and the beta configuration are accepted, the fields we care about are the same
+	apiVersion, ok, err := unstructured.NestedString(r.Object, "apiVersion")
+	if err != nil {
+		klog.ErrorS(err, "failed to process unstructured data")
+		return params, err
+	}
+	if ok {
+		if err := ValidateSchedulerConfigAPIVersion(apiVersion); err != nil {
+			return params, err
+		}
+	}
+
 	lead, ok, err := unstructured.NestedMap(r.Object, "leaderElection")
 	if err != nil {
 		klog.ErrorS(err, "failed to process unstructured data")
@@ -389,6 +472,28 @@ func extractLeaderElectionParams(lead map[string]interface{}) (*LeaderElectionPa
 		params.ResourceName = resourceName
 	}
 
+	for _, item := range []struct {
+		field string
+		dest  **metav1.Duration
+	}{
+		{"leaseDuration", &params.LeaseDuration},
+		{"renewDeadline", &params.RenewDeadline},
+		{"retryPeriod", &params.RetryPeriod},
+	} {
+		value, ok, err := unstructured.NestedString(lead, item.field)
+		if err != nil {
+			return &params, fmt.Errorf("unexpected %s data: %w", item.field, err)
+		}
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return &params, fmt.Errorf("unexpected %s data: %w", item.field, err)
+		}
+		*item.dest = &metav1.Duration{Duration: d}
+	}
+
 	return &params, nil
 }
 
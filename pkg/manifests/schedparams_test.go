@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 )
 
 func TestDecodeSchedulerConfigFromData(t *testing.T) {
@@ -683,3 +685,74 @@ func toJSON(v any) string {
 	}
 	return string(data)
 }
+
+func TestDecodeSchedulerConfigAPIVersions(t *testing.T) {
+	type testCase struct {
+		apiVersion    string
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{apiVersion: SchedulerConfigAPIVersionV1Beta3},
+		{apiVersion: SchedulerConfigAPIVersionV1},
+		{apiVersion: "kubescheduler.config.k8s.io/v1beta2", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.apiVersion, func(t *testing.T) {
+			data := []byte(`apiVersion: ` + tc.apiVersion + `
+kind: KubeSchedulerConfiguration
+leaderElection:
+  leaderElect: false
+profiles:
+- pluginConfig:
+  - args:
+      cacheResyncPeriodSeconds: 5
+    name: NodeResourceTopologyMatch
+  schedulerName: topology-aware-scheduler
+`)
+			allParams, err := DecodeSchedulerProfilesFromData(data)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("decode succeeded with unsupported apiVersion")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error [%v]", err)
+			}
+			params := FindSchedulerProfileByName(allParams, "topology-aware-scheduler")
+			if params == nil || params.Cache.ResyncPeriodSeconds == nil || *params.Cache.ResyncPeriodSeconds != 5 {
+				t.Fatalf("unexpected params: %s", toJSON(allParams))
+			}
+		})
+	}
+}
+
+func TestSchedulerConfigAPIVersionForKubernetes(t *testing.T) {
+	type testCase struct {
+		version    platform.Version
+		expected   string
+		expectedOK bool
+	}
+
+	testCases := []testCase{
+		{version: "1.24", expected: SchedulerConfigAPIVersionV1Beta3, expectedOK: true},
+		{version: "v1.24.17", expected: SchedulerConfigAPIVersionV1Beta3, expectedOK: true},
+		{version: "1.25", expected: SchedulerConfigAPIVersionV1, expectedOK: true},
+		{version: "v1.30.2", expected: SchedulerConfigAPIVersionV1, expectedOK: true},
+		{version: "foo"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.version), func(t *testing.T) {
+			got, err := SchedulerConfigAPIVersionForKubernetes(tc.version)
+			if (err == nil) != tc.expectedOK {
+				t.Fatalf("unexpected error state: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("got %q expected %q", got, tc.expected)
+			}
+		})
+	}
+}
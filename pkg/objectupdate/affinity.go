@@ -16,7 +16,10 @@
 
 package objectupdate
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 const (
 	NodeRoleControlPlane           = "node-role.kubernetes.io/control-plane"
@@ -61,6 +64,108 @@ func SetPodSchedulerAffinityOnControlPlane(podSpec *corev1.PodSpec) {
 	}
 }
 
+// SetPodSpreadAcrossNodes requires the pods matching the given labels to run on different nodes,
+// and spreads them across zones when the nodes are labeled with their zone.
+func SetPodSpreadAcrossNodes(podSpec *corev1.PodSpec, podLabels map[string]string) {
+	if podSpec == nil || len(podLabels) == 0 {
+		return
+	}
+	sel := &metav1.LabelSelector{
+		MatchLabels: make(map[string]string, len(podLabels)),
+	}
+	for key, value := range podLabels {
+		sel.MatchLabels[key] = value
+	}
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	podSpec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+			{
+				LabelSelector: sel,
+				TopologyKey:   corev1.LabelHostname,
+			},
+		},
+	}
+	podSpec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelTopologyZone,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     sel.DeepCopy(),
+		},
+	}
+}
+
+// SetPodNodeSelector constrains the pod to the nodes matching the selector. Labels are set as node selector,
+// expressions are set as required node affinity, replacing the existing one.
+func SetPodNodeSelector(podSpec *corev1.PodSpec, sel *metav1.LabelSelector) {
+	if podSpec == nil || sel == nil {
+		return
+	}
+	if len(sel.MatchLabels) > 0 {
+		podSpec.NodeSelector = make(map[string]string, len(sel.MatchLabels))
+		for key, value := range sel.MatchLabels {
+			podSpec.NodeSelector[key] = value
+		}
+	}
+	if len(sel.MatchExpressions) == 0 {
+		return
+	}
+	term := corev1.NodeSelectorTerm{}
+	for _, expr := range sel.MatchExpressions {
+		term.MatchExpressions = append(term.MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      expr.Key,
+			Operator: corev1.NodeSelectorOperator(expr.Operator),
+			Values:   append([]string{}, expr.Values...),
+		})
+	}
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+		NodeSelectorTerms: []corev1.NodeSelectorTerm{term},
+	}
+}
+
+// SetPodTolerations adds the given tolerations to the pod, skipping the ones already present.
+func SetPodTolerations(podSpec *corev1.PodSpec, tolerations []corev1.Toleration) {
+	if podSpec == nil {
+		return
+	}
+	for _, tol := range tolerations {
+		if hasToleration(podSpec.Tolerations, tol) {
+			continue
+		}
+		podSpec.Tolerations = append(podSpec.Tolerations, tol)
+	}
+}
+
+// SetPodImagePullSecret adds the given secret to the pod image pull secrets, if not already present.
+func SetPodImagePullSecret(podSpec *corev1.PodSpec, name string) {
+	if podSpec == nil || name == "" {
+		return
+	}
+	for _, ref := range podSpec.ImagePullSecrets {
+		if ref.Name == name {
+			return
+		}
+	}
+	podSpec.ImagePullSecrets = append(podSpec.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+}
+
+func hasToleration(tolerations []corev1.Toleration, tol corev1.Toleration) bool {
+	for idx := range tolerations {
+		if tolerations[idx].MatchToleration(&tol) {
+			return true
+		}
+	}
+	return false
+}
+
 func findTolerationByKey(tolerations []corev1.Toleration, key string) *corev1.Toleration {
 	for idx := range tolerations {
 		toleration := &tolerations[idx]
@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestSetPodSchedulerAffinityOnControlPlane(t *testing.T) {
@@ -95,3 +96,115 @@ tolerations:
 - effect: NoSchedule
   key: node-role.kubernetes.io/master
 `
+
+func TestSetPodNodeSelector(t *testing.T) {
+	type testCase struct {
+		name         string
+		podSpec      *corev1.PodSpec
+		selector     *metav1.LabelSelector
+		expectedYAML string
+	}
+
+	testCases := []testCase{
+		{
+			name:         "nil selector",
+			podSpec:      &corev1.PodSpec{},
+			expectedYAML: "containers: null\n",
+		},
+		{
+			name:    "labels only",
+			podSpec: &corev1.PodSpec{},
+			selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"pool": "numa"},
+			},
+			expectedYAML: `containers: null
+nodeSelector:
+  pool: numa
+`,
+		},
+		{
+			name:    "expressions",
+			podSpec: &corev1.PodSpec{},
+			selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "pool",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"numa", "hpc"},
+					},
+				},
+			},
+			expectedYAML: `affinity:
+  nodeAffinity:
+    requiredDuringSchedulingIgnoredDuringExecution:
+      nodeSelectorTerms:
+      - matchExpressions:
+        - key: pool
+          operator: In
+          values:
+          - numa
+          - hpc
+containers: null
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.podSpec.DeepCopy()
+			SetPodNodeSelector(got, tc.selector)
+			data, err := yaml.Marshal(got)
+			if err != nil {
+				t.Errorf("error marshalling yaml: %v", err)
+			}
+			gotYAML := string(data)
+			if gotYAML != tc.expectedYAML {
+				t.Errorf("output mismatch:\ngot=%v\nexpected=%v\n", gotYAML, tc.expectedYAML)
+			}
+		})
+	}
+}
+
+func TestSetPodTolerations(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Tolerations: []corev1.Toleration{
+			{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			},
+		},
+	}
+	SetPodTolerations(podSpec, []corev1.Toleration{
+		{
+			Key:      "dedicated",
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		},
+		{
+			Key:      "numa",
+			Operator: corev1.TolerationOpEqual,
+			Value:    "true",
+			Effect:   corev1.TaintEffectNoExecute,
+		},
+	})
+	if len(podSpec.Tolerations) != 2 {
+		t.Fatalf("unexpected tolerations: %#v", podSpec.Tolerations)
+	}
+	if podSpec.Tolerations[1].Key != "numa" {
+		t.Errorf("unexpected toleration added: %#v", podSpec.Tolerations[1])
+	}
+}
+
+func TestSetPodImagePullSecret(t *testing.T) {
+	podSpec := &corev1.PodSpec{}
+	SetPodImagePullSecret(podSpec, "")
+	if len(podSpec.ImagePullSecrets) != 0 {
+		t.Fatalf("unexpected pull secrets: %#v", podSpec.ImagePullSecrets)
+	}
+	SetPodImagePullSecret(podSpec, "regcred")
+	SetPodImagePullSecret(podSpec, "regcred")
+	if len(podSpec.ImagePullSecrets) != 1 || podSpec.ImagePullSecrets[0].Name != "regcred" {
+		t.Errorf("unexpected pull secrets: %#v", podSpec.ImagePullSecrets)
+	}
+}
@@ -49,3 +49,43 @@ func FindContainerPortByName(ports []corev1.ContainerPort, name string) *corev1.
 	}
 	return nil
 }
+
+func FindVolumeByName(vols []corev1.Volume, name string) *corev1.Volume {
+	for idx := range vols {
+		vol := &vols[idx]
+		if vol.Name == name {
+			return vol
+		}
+	}
+	return nil
+}
+
+func FindVolumeMountByName(mounts []corev1.VolumeMount, name string) *corev1.VolumeMount {
+	for idx := range mounts {
+		vm := &mounts[idx]
+		if vm.Name == name {
+			return vm
+		}
+	}
+	return nil
+}
+
+// SetHostPathVolume points the host path volume with the given name, if any, to the given path.
+func SetHostPathVolume(podSpec *corev1.PodSpec, name, path string) {
+	vol := FindVolumeByName(podSpec.Volumes, name)
+	if vol == nil || vol.HostPath == nil {
+		return
+	}
+	vol.HostPath.Path = path
+}
+
+// SetMountPropagation sets the propagation mode of the mounts of the given volume in all the containers.
+func SetMountPropagation(podSpec *corev1.PodSpec, name string, mode corev1.MountPropagationMode) {
+	for idx := range podSpec.Containers {
+		vm := FindVolumeMountByName(podSpec.Containers[idx].VolumeMounts, name)
+		if vm == nil {
+			continue
+		}
+		vm.MountPropagation = &mode
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package objectupdate
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
+)
+
+// PodSpecOf returns the pod spec of the objects running pods, nil otherwise.
+func PodSpecOf(obj client.Object) *corev1.PodSpec {
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		return &o.Spec
+	case *appsv1.Deployment:
+		return &o.Spec.Template.Spec
+	case *appsv1.DaemonSet:
+		return &o.Spec.Template.Spec
+	case *appsv1.StatefulSet:
+		return &o.Spec.Template.Spec
+	case *batchv1.Job:
+		return &o.Spec.Template.Spec
+	}
+	return nil
+}
+
+// RewriteImages pulls the images of all the containers, including the init and the ephemeral ones, from the mirrors.
+func RewriteImages(objs []client.Object, mirrors images.Mirrors) {
+	if len(mirrors) == 0 {
+		return
+	}
+	for _, obj := range objs {
+		podSpec := PodSpecOf(obj)
+		if podSpec == nil {
+			continue
+		}
+		for idx := range podSpec.InitContainers {
+			podSpec.InitContainers[idx].Image, _ = mirrors.Rewrite(podSpec.InitContainers[idx].Image)
+		}
+		for idx := range podSpec.Containers {
+			podSpec.Containers[idx].Image, _ = mirrors.Rewrite(podSpec.Containers[idx].Image)
+		}
+		for idx := range podSpec.EphemeralContainers {
+			podSpec.EphemeralContainers[idx].Image, _ = mirrors.Rewrite(podSpec.EphemeralContainers[idx].Image)
+		}
+	}
+}
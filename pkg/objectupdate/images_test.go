@@ -0,0 +1,65 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package objectupdate
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
+)
+
+func TestRewriteImages(t *testing.T) {
+	ds := &appsv1.DaemonSet{
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{Name: "init", Image: "registry.k8s.io/busybox:1.36"},
+					},
+					Containers: []corev1.Container{
+						{Name: "main", Image: "quay.io/example/main:v1"},
+						{Name: "sidecar", Image: "gcr.io/google_containers/pause-amd64:3.0"},
+					},
+				},
+			},
+		},
+	}
+	cm := &corev1.ConfigMap{}
+
+	mirrors := images.Mirrors{
+		{Source: "registry.k8s.io", Mirror: "mirror.example.com/k8s"},
+		{Source: "gcr.io", Mirror: "mirror.example.com/gcr"},
+	}
+	RewriteImages([]client.Object{ds, cm}, mirrors)
+
+	podSpec := ds.Spec.Template.Spec
+	expected := map[string]string{
+		"init":    "mirror.example.com/k8s/busybox:1.36",
+		"main":    "quay.io/example/main:v1",
+		"sidecar": "mirror.example.com/gcr/google_containers/pause-amd64:3.0",
+	}
+	for _, cnt := range append(podSpec.InitContainers, podSpec.Containers...) {
+		if cnt.Image != expected[cnt.Name] {
+			t.Errorf("container %q: image got=%q expected=%q", cnt.Name, cnt.Image, expected[cnt.Name])
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package objectupdate
+
+import (
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+// APIServerNetworkPolicy allows the egress traffic to the API server endpoints only.
+// Empty addresses allow any destination, empty ports use the default API server port.
+func APIServerNetworkPolicy(np *networkingv1.NetworkPolicy, addresses []string, ports []int32) {
+	if len(ports) == 0 {
+		ports = []int32{manifests.DefaultAPIServerPort}
+	}
+	rule := networkingv1.NetworkPolicyEgressRule{}
+	for _, addr := range addresses {
+		rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{
+				CIDR: hostCIDR(addr),
+			},
+		})
+	}
+	for _, portNum := range ports {
+		proto := corev1.ProtocolTCP
+		port := intstr.FromInt32(portNum)
+		rule.Ports = append(rule.Ports, networkingv1.NetworkPolicyPort{
+			Protocol: &proto,
+			Port:     &port,
+		})
+	}
+	np.Spec.Egress = []networkingv1.NetworkPolicyEgressRule{rule}
+}
+
+// hostCIDR returns the single host CIDR of an IP address. CIDRs are returned unchanged.
+func hostCIDR(addr string) string {
+	if strings.Contains(addr, "/") {
+		return addr
+	}
+	ip := net.ParseIP(addr)
+	if ip != nil && ip.To4() == nil {
+		return addr + "/128"
+	}
+	return addr + "/32"
+}
@@ -0,0 +1,84 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package objectupdate
+
+import (
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func TestAPIServerNetworkPolicy(t *testing.T) {
+	type testCase struct {
+		name          string
+		addresses     []string
+		ports         []int32
+		expectedCIDRs []string
+		expectedPorts []int
+	}
+
+	testCases := []testCase{
+		{
+			name:          "defaults",
+			expectedPorts: []int{6443},
+		},
+		{
+			name:          "ports only",
+			ports:         []int32{443, 6443},
+			expectedPorts: []int{443, 6443},
+		},
+		{
+			name:          "addresses and CIDRs",
+			addresses:     []string{"10.0.0.1", "fd00::1", "192.168.0.0/24"},
+			ports:         []int32{443},
+			expectedCIDRs: []string{"10.0.0.1/32", "fd00::1/128", "192.168.0.0/24"},
+			expectedPorts: []int{443},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			np := &networkingv1.NetworkPolicy{
+				Spec: networkingv1.NetworkPolicySpec{
+					Egress: []networkingv1.NetworkPolicyEgressRule{{}, {}},
+				},
+			}
+			APIServerNetworkPolicy(np, tc.addresses, tc.ports)
+
+			if len(np.Spec.Egress) != 1 {
+				t.Fatalf("expected a single egress rule, got %d", len(np.Spec.Egress))
+			}
+			rule := np.Spec.Egress[0]
+			if len(rule.To) != len(tc.expectedCIDRs) {
+				t.Fatalf("got %d peers expected %d", len(rule.To), len(tc.expectedCIDRs))
+			}
+			for idx, peer := range rule.To {
+				if peer.IPBlock == nil || peer.IPBlock.CIDR != tc.expectedCIDRs[idx] {
+					t.Errorf("peer %d: got %+v expected CIDR %q", idx, peer, tc.expectedCIDRs[idx])
+				}
+			}
+			if len(rule.Ports) != len(tc.expectedPorts) {
+				t.Fatalf("got %d ports expected %d", len(rule.Ports), len(tc.expectedPorts))
+			}
+			for idx, port := range rule.Ports {
+				if port.Port.IntValue() != tc.expectedPorts[idx] {
+					t.Errorf("port %d: got %v expected %d", idx, port.Port, tc.expectedPorts[idx])
+				}
+			}
+		})
+	}
+}
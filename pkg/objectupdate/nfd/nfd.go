@@ -18,24 +18,34 @@ package nfd
 
 import (
 	"fmt"
+	"path/filepath"
 	"strconv"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/flagcodec"
-	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
-func UpdaterDaemonSet(ds *appsv1.DaemonSet, opts options.DaemonSet) {
+const (
+	kubeletConfigVolumeName = "kubelet-podresources-conf"
+	podresourcesVolumeName  = "kubelet-podresources"
+	sysVolumeName           = "host-sys"
+)
+
+func UpdaterDaemonSet(ds *appsv1.DaemonSet, opts options.DaemonSet) error {
+	objectupdate.SetPodImagePullSecret(&ds.Spec.Template.Spec, opts.ImagePullSecret)
 	if c := objectupdate.FindContainerByName(ds.Spec.Template.Spec.Containers, manifests.ContainerNameNFDTopologyUpdater); c != nil {
 		c.ImagePullPolicy = corev1.PullAlways
 		if opts.PullIfNotPresent {
 			c.ImagePullPolicy = corev1.PullIfNotPresent
 		}
+		if err := objectupdate.SetContainerResources(c, opts.NFDResources); err != nil {
+			return err
+		}
 
 		flags := flagcodec.ParseArgvKeyValue(c.Args, flagcodec.WithFlagNormalization)
 		flags.SetOption("-v", fmt.Sprintf("%d", opts.Verbose))
@@ -52,11 +62,18 @@ func UpdaterDaemonSet(ds *appsv1.DaemonSet, opts options.DaemonSet) {
 
 		c.Args = flags.Argv()
 
-		imgs := images.Get()
-		c.Image = imgs.NodeFeatureDiscovery
+		c.Image = opts.Images.OrGet().NodeFeatureDiscovery
 	}
 
-	if opts.NodeSelector != nil {
-		ds.Spec.Template.Spec.NodeSelector = opts.NodeSelector.MatchLabels
+	podSpec := &ds.Spec.Template.Spec
+	flavorDefaults := opts.Flavor.Defaults().WithKubeletConfigFile(opts.KubeletConfigFile)
+	objectupdate.SetHostPathVolume(podSpec, kubeletConfigVolumeName, filepath.Join(flavorDefaults.KubeletConfigDir, flavorDefaults.KubeletConfigFile))
+	objectupdate.SetHostPathVolume(podSpec, podresourcesVolumeName, flavorDefaults.PodResourcesDir)
+	if flavorDefaults.SysfsMountPropagation {
+		objectupdate.SetMountPropagation(podSpec, sysVolumeName, corev1.MountPropagationHostToContainer)
 	}
+
+	objectupdate.SetPodNodeSelector(podSpec, opts.NodeSelector)
+	objectupdate.SetPodTolerations(podSpec, opts.Tolerations)
+	return nil
 }
@@ -0,0 +1,52 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package objectupdate
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SetContainerResources merges the given requests and limits into the container resources:
+// the resources set in res override the ones of the container, the others are left untouched.
+// Returns error if a merged request exceeds the merged limit, which the API server would reject:
+// a valid override may still conflict with the embedded defaults.
+func SetContainerResources(cnt *corev1.Container, res corev1.ResourceRequirements) error {
+	if cnt == nil {
+		return nil
+	}
+	if len(res.Requests) > 0 && cnt.Resources.Requests == nil {
+		cnt.Resources.Requests = make(corev1.ResourceList)
+	}
+	for name, qty := range res.Requests {
+		cnt.Resources.Requests[name] = qty
+	}
+	if len(res.Limits) > 0 && cnt.Resources.Limits == nil {
+		cnt.Resources.Limits = make(corev1.ResourceList)
+	}
+	for name, qty := range res.Limits {
+		cnt.Resources.Limits[name] = qty
+	}
+	for name, req := range cnt.Resources.Requests {
+		lim, ok := cnt.Resources.Limits[name]
+		if ok && req.Cmp(lim) > 0 {
+			return fmt.Errorf("container %q: resource %q: request %s exceeds limit %s", cnt.Name, name, req.String(), lim.String())
+		}
+	}
+	return nil
+}
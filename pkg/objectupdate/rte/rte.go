@@ -23,20 +23,27 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	selinuxassets "github.com/k8stopologyawareschedwg/deployer/pkg/assets/selinux"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/flagcodec"
-	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/options"
 )
 
 const (
-	metricsPort                  = 2112
-	metricsPortEnvVarName        = "METRICS_PORT"
-	metricsPortContainerPortName = "metrics-port"
+	// DefaultMetricsPort is the port RTE serves its metrics on
+	DefaultMetricsPort    = 2112
+	metricsPortEnvVarName = "METRICS_PORT"
+	// MetricsPortName is the name of the RTE container metrics port
+	MetricsPortName = "metrics-port"
+	// metricsTLSCertDir is where RTE looks for tls.crt and tls.key when serving the metrics over HTTPS
+	metricsTLSCertDir    = "/etc/secrets/rte/"
+	metricsTLSVolumeName = "rte-metrics-tls"
+	metricsModeHTTPTLS   = "httptls"
 )
 
 const (
@@ -52,7 +59,6 @@ const (
 	rteKubeletDirVolumeName      = "host-var-lib-kubelet"
 	rteNotifierFileName          = "notify"
 	hostNotifierDir              = "/run/rte"
-	kubeletDataDir               = "/var/lib/kubelet"
 	sccAnnotation                = "openshift.io/required-scc"
 )
 
@@ -80,18 +86,52 @@ func ContainerConfig(podSpec *corev1.PodSpec, cnt *corev1.Container, configMapNa
 	)
 }
 
-func DaemonSet(ds *appsv1.DaemonSet, plat platform.Platform, configMapName string, opts options.DaemonSet) {
+func DaemonSet(ds *appsv1.DaemonSet, plat platform.Platform, configMapName string, opts options.DaemonSet) error {
 	podSpec := &ds.Spec.Template.Spec
-	if opts.NodeSelector != nil {
-		podSpec.NodeSelector = opts.NodeSelector.MatchLabels
-	}
+	objectupdate.SetPodNodeSelector(podSpec, opts.NodeSelector)
+	objectupdate.SetPodTolerations(podSpec, opts.Tolerations)
+	objectupdate.SetPodImagePullSecret(podSpec, opts.ImagePullSecret)
 
 	cntSpec := objectupdate.FindContainerByName(ds.Spec.Template.Spec.Containers, manifests.ContainerNameRTE)
 	if cntSpec == nil {
-		return // should never happen
+		return nil // should never happen
+	}
+	if err := objectupdate.SetContainerResources(cntSpec, opts.RTEResources); err != nil {
+		return err
+	}
+	if pauseSpec := objectupdate.FindContainerByName(podSpec.Containers, manifests.ContainerNameSharedPool); pauseSpec != nil {
+		pauseSpec.Image = opts.Images.OrGet().Pause
+		if err := objectupdate.SetContainerResources(pauseSpec, opts.PauseResources); err != nil {
+			return err
+		}
 	}
 
 	daemonSetContainerConfig(podSpec, cntSpec, plat, configMapName, opts)
+	return nil
+}
+
+// MetricsPortFromOptions returns the port RTE serves its metrics on
+func MetricsPortFromOptions(opts options.DaemonSet) int {
+	if opts.MetricsPort > 0 {
+		return opts.MetricsPort
+	}
+	return DefaultMetricsPort
+}
+
+// MetricsNetworkPolicy allows the ingress traffic to the metrics port only
+func MetricsNetworkPolicy(np *networkingv1.NetworkPolicy, portNum int) {
+	proto := corev1.ProtocolTCP
+	port := intstr.FromInt32(int32(portNum))
+	np.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Protocol: &proto,
+					Port:     &port,
+				},
+			},
+		},
+	}
 }
 
 func MetricsPort(ds *appsv1.DaemonSet, portNum int) {
@@ -158,10 +198,9 @@ func securityContextForContainer(podTmpl *corev1.PodTemplateSpec, cntSpec *corev
 }
 
 func daemonSetContainerConfig(podSpec *corev1.PodSpec, cntSpec *corev1.Container, plat platform.Platform, configMapName string, opts options.DaemonSet) {
-	metricsPortForContainer(cntSpec, metricsPort)
+	metricsPortForContainer(cntSpec, MetricsPortFromOptions(opts))
 
-	imgs := images.Get()
-	cntSpec.Image = imgs.ResourceTopologyExporter
+	cntSpec.Image = opts.Images.OrGet().ResourceTopologyExporter
 
 	cntSpec.ImagePullPolicy = corev1.PullAlways
 	if opts.PullIfNotPresent {
@@ -193,13 +232,35 @@ func daemonSetContainerConfig(podSpec *corev1.PodSpec, cntSpec *corev1.Container
 		})
 	}
 
+	if opts.MetricsTLS.Enabled() {
+		rtePodVolumes = append(rtePodVolumes, corev1.Volume{
+			Name: metricsTLSVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: opts.MetricsTLS.SecretName,
+				},
+			},
+		})
+		rteContainerVolumeMounts = append(rteContainerVolumeMounts, corev1.VolumeMount{
+			Name:      metricsTLSVolumeName,
+			ReadOnly:  true,
+			MountPath: metricsTLSCertDir,
+		})
+	}
+
+	flavorDefaults := opts.Flavor.Defaults().WithKubeletConfigFile(opts.KubeletConfigFile)
+	objectupdate.SetHostPathVolume(podSpec, rtePodresourcesDirVolumeName, flavorDefaults.PodResourcesDir)
+	if flavorDefaults.SysfsMountPropagation {
+		objectupdate.SetMountPropagation(podSpec, rteSysVolumeName, corev1.MountPropagationHostToContainer)
+	}
+
 	if plat == platform.Kubernetes {
 		hostPathDirectory := corev1.HostPathDirectory
 		rtePodVolumes = append(rtePodVolumes, corev1.Volume{
 			Name: rteKubeletDirVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				HostPath: &corev1.HostPathVolumeSource{
-					Path: kubeletDataDir,
+					Path: flavorDefaults.KubeletConfigDir,
 					Type: &hostPathDirectory,
 				},
 			},
@@ -223,9 +284,14 @@ func daemonSetContainerConfig(podSpec *corev1.PodSpec, cntSpec *corev1.Container
 	}
 
 	flags.SetOption("--pods-fingerprint", strconv.FormatBool(opts.PFPEnable))
+	if opts.MetricsTLS.Enabled() {
+		flags.SetOption("--metrics-mode", metricsModeHTTPTLS)
+	} else {
+		flags.Delete("--metrics-mode")
+	}
 
 	if plat == platform.Kubernetes {
-		flags.SetOption("--kubelet-config-file", fmt.Sprintf("/%s/config.yaml", rteKubeletDirVolumeName))
+		flags.SetOption("--kubelet-config-file", fmt.Sprintf("/%s/%s", rteKubeletDirVolumeName, flavorDefaults.KubeletConfigFile))
 	}
 	cntSpec.Args = flags.Argv()
 
@@ -244,12 +310,12 @@ func metricsPortForContainer(cntSpec *corev1.Container, portNum int) {
 		})
 	}
 
-	cp := objectupdate.FindContainerPortByName(cntSpec.Ports, metricsPortContainerPortName)
+	cp := objectupdate.FindContainerPortByName(cntSpec.Ports, MetricsPortName)
 	if cp != nil {
 		cp.ContainerPort = int32(portNum)
 	} else {
 		cntSpec.Ports = append(cntSpec.Ports, corev1.ContainerPort{
-			Name:          metricsPortContainerPortName,
+			Name:          MetricsPortName,
 			ContainerPort: int32(portNum),
 		})
 	}
@@ -154,6 +154,7 @@ func TestDaemonSet(t *testing.T) {
 	type testCase struct {
 		name                 string
 		plat                 platform.Platform
+		flavor               platform.Flavor
 		pfpEnable            bool
 		expectedCommandArgs  []string
 		expectedVolumes      map[string]string
@@ -250,6 +251,29 @@ func TestDaemonSet(t *testing.T) {
 				rteNotifierVolumeName:        fmt.Sprintf("/%s", rteNotifierVolumeName),
 			},
 		},
+		{
+			name:   "Verify DaemonSet generation for Kubernetes platform, K3s flavor",
+			plat:   platform.Kubernetes,
+			flavor: platform.FlavorK3s,
+			expectedCommandArgs: []string{
+				fmt.Sprintf("--sysfs=%s", containerHostSysDir),
+				fmt.Sprintf("--podresources-socket=unix:///%s/%s", rtePodresourcesDirVolumeName, "kubelet.sock"),
+				fmt.Sprintf("--kubelet-config-file=/%s/00-k3s-defaults.conf", rteKubeletDirVolumeName),
+				"--pods-fingerprint=false",
+			},
+			expectedVolumes: map[string]string{
+				rteSysVolumeName:             "/sys",
+				rtePodresourcesDirVolumeName: "/var/lib/kubelet/pod-resources",
+				rteKubeletDirVolumeName:      "/var/lib/rancher/k3s/agent/etc/kubelet.conf.d",
+				rteNotifierVolumeName:        "/run/rte",
+			},
+			expectedVolumeMounts: map[string]string{
+				rteSysVolumeName:             containerHostSysDir,
+				rtePodresourcesDirVolumeName: fmt.Sprintf("/%s", rtePodresourcesDirVolumeName),
+				rteKubeletDirVolumeName:      fmt.Sprintf("/%s", rteKubeletDirVolumeName),
+				rteNotifierVolumeName:        fmt.Sprintf("/%s", rteNotifierVolumeName),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -259,6 +283,7 @@ func TestDaemonSet(t *testing.T) {
 				t.Fatalf("unexpected error getting the manifests: %v", err)
 			}
 			DaemonSet(ds, tc.plat, "", options.DaemonSet{
+				Flavor:             tc.flavor,
 				PFPEnable:          tc.pfpEnable,
 				NotificationEnable: true,
 				UpdateInterval:     10 * time.Second,
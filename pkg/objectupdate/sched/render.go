@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
 
@@ -62,6 +63,17 @@ func RenderConfig(data []byte, schedulerName string, params *manifests.ConfigPar
 
 	updated := false
 
+	if params.APIVersion != "" {
+		apiVersionUpdated, err := updateAPIVersion(r.Object, params.APIVersion)
+		if err != nil {
+			klog.ErrorS(err, "failed to update unstructured data", "apiVersion", params.APIVersion)
+			return data, false, err
+		}
+		if apiVersionUpdated {
+			updated = true
+		}
+	}
+
 	if params.LeaderElection != nil {
 		lead, ok, err := unstructured.NestedMap(r.Object, "leaderElection")
 		if !ok || err != nil {
@@ -176,6 +188,33 @@ func RenderConfig(data []byte, schedulerName string, params *manifests.ConfigPar
 	return newData, updated, nil
 }
 
+// fieldsOnlyV1 are the KubeSchedulerConfiguration fields which the beta API does not know about
+var fieldsOnlyV1 = []string{
+	"delayCacheUntilActive",
+}
+
+func updateAPIVersion(obj map[string]interface{}, apiVersion string) (bool, error) {
+	if err := manifests.ValidateSchedulerConfigAPIVersion(apiVersion); err != nil {
+		return false, err
+	}
+	curVersion, _, err := unstructured.NestedString(obj, "apiVersion")
+	if err != nil {
+		return false, err
+	}
+	if curVersion == apiVersion {
+		return false, nil
+	}
+	if err := unstructured.SetNestedField(obj, apiVersion, "apiVersion"); err != nil {
+		return false, err
+	}
+	if apiVersion == manifests.SchedulerConfigAPIVersionV1Beta3 {
+		for _, field := range fieldsOnlyV1 {
+			unstructured.RemoveNestedField(obj, field)
+		}
+	}
+	return true, nil
+}
+
 func updateLeaderElection(lead map[string]interface{}, params *manifests.ConfigParams) (bool, error) {
 	var updated int
 	var err error
@@ -198,6 +237,21 @@ func updateLeaderElection(lead map[string]interface{}, params *manifests.ConfigP
 	}
 	updated++
 
+	for field, value := range map[string]*metav1.Duration{
+		"leaseDuration": params.LeaderElection.LeaseDuration,
+		"renewDeadline": params.LeaderElection.RenewDeadline,
+		"retryPeriod":   params.LeaderElection.RetryPeriod,
+	} {
+		if value == nil {
+			continue
+		}
+		err = unstructured.SetNestedField(lead, value.Duration.String(), field)
+		if err != nil {
+			return updated > 0, err
+		}
+		updated++
+	}
+
 	return updated > 0, nil
 
 }
@@ -17,9 +17,14 @@
 package sched
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 )
 
@@ -694,6 +699,72 @@ profiles:
 `,
 			expectedUpdate: true,
 		},
+		{
+			name: "leader election lease durations",
+			params: &manifests.ConfigParams{
+				LeaderElection: &manifests.LeaderElectionParams{
+					LeaderElect:       true,
+					ResourceNamespace: "numaresources",
+					ResourceName:      "nrtmatch-scheduler",
+					LeaseDuration:     &metav1.Duration{Duration: 30 * time.Second},
+					RenewDeadline:     &metav1.Duration{Duration: 20 * time.Second},
+					RetryPeriod:       &metav1.Duration{Duration: 4 * time.Second},
+				},
+			},
+			initial: configTemplateEmpty,
+			expected: `apiVersion: kubescheduler.config.k8s.io/v1beta3
+kind: KubeSchedulerConfiguration
+leaderElection:
+  leaderElect: true
+  leaseDuration: 30s
+  renewDeadline: 20s
+  resourceName: nrtmatch-scheduler
+  resourceNamespace: numaresources
+  retryPeriod: 4s
+profiles:
+- pluginConfig:
+  - args: {}
+    name: NodeResourceTopologyMatch
+  plugins:
+    filter:
+      enabled:
+      - name: NodeResourceTopologyMatch
+    reserve:
+      enabled:
+      - name: NodeResourceTopologyMatch
+    score:
+      enabled:
+      - name: NodeResourceTopologyMatch
+  schedulerName: test-sched-name
+`,
+			expectedUpdate: true,
+		},
+		{
+			name: "apiVersion upgraded to GA",
+			params: &manifests.ConfigParams{
+				APIVersion: manifests.SchedulerConfigAPIVersionV1,
+			},
+			initial:        configTemplateEmpty,
+			expected:       strings.Replace(configTemplateEmpty, manifests.SchedulerConfigAPIVersionV1Beta3, manifests.SchedulerConfigAPIVersionV1, 1),
+			expectedUpdate: true,
+		},
+		{
+			name: "apiVersion unchanged",
+			params: &manifests.ConfigParams{
+				APIVersion: manifests.SchedulerConfigAPIVersionV1Beta3,
+			},
+			initial:  configTemplateEmpty,
+			expected: configTemplateEmpty,
+		},
+		{
+			name: "apiVersion downgraded to beta drops GA-only fields",
+			params: &manifests.ConfigParams{
+				APIVersion: manifests.SchedulerConfigAPIVersionV1Beta3,
+			},
+			initial:        strings.Replace(configTemplateEmpty, "apiVersion: "+manifests.SchedulerConfigAPIVersionV1Beta3+"\n", "apiVersion: "+manifests.SchedulerConfigAPIVersionV1+"\ndelayCacheUntilActive: true\n", 1),
+			expected:       configTemplateEmpty,
+			expectedUpdate: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -918,3 +989,16 @@ func newInt64(value int64) *int64 {
 func newString(value string) *string {
 	return &value
 }
+
+func TestRenderConfigUnsupportedAPIVersion(t *testing.T) {
+	params := &manifests.ConfigParams{
+		APIVersion: "kubescheduler.config.k8s.io/v1beta2",
+	}
+	data, ok, err := RenderConfig([]byte(configTemplateEmpty), "test-sched-name", params)
+	if err == nil {
+		t.Fatalf("RenderConfig() succeeded with unsupported apiVersion")
+	}
+	if ok || string(data) != configTemplateEmpty {
+		t.Errorf("RenderConfig() modified the data on failure")
+	}
+}
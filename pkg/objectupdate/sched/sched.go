@@ -18,20 +18,34 @@ package sched
 
 import (
 	"fmt"
+	"path"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/flagcodec"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/objectupdate"
 )
 
-func SchedulerDeployment(dp *appsv1.Deployment, pullIfNotPresent, ctrlPlaneAffinity bool, verbose int) {
-	imgs := images.Get()
+const (
+	// servingCertDir is where the scheduler reads its serving certificate, when not self-signed
+	servingCertDir        = "/etc/secrets/scheduler"
+	servingCertVolumeName = "scheduler-serving-cert"
+)
+
+const (
+	// SecurePort is the port the scheduler serves its health checks and its metrics on
+	SecurePort = 10259
+	// SecurePortName names the scheduler secure port, so the metrics monitors can reference it
+	SecurePortName = "https"
+)
+
+func SchedulerDeployment(dp *appsv1.Deployment, imgs images.Images, pullIfNotPresent, ctrlPlaneAffinity bool, verbose int) {
 	cnt := &dp.Spec.Template.Spec.Containers[0] // shortcut
 
-	cnt.Image = imgs.SchedulerPluginScheduler
+	cnt.Image = imgs.OrGet().SchedulerPluginScheduler
 	cnt.ImagePullPolicy = pullPolicy(pullIfNotPresent)
 
 	flags := flagcodec.ParseArgvKeyValue(cnt.Args, flagcodec.WithFlagNormalization)
@@ -43,9 +57,8 @@ func SchedulerDeployment(dp *appsv1.Deployment, pullIfNotPresent, ctrlPlaneAffin
 	}
 }
 
-func ControllerDeployment(dp *appsv1.Deployment, pullIfNotPresent, ctrlPlaneAffinity bool) {
-	imgs := images.Get()
-	dp.Spec.Template.Spec.Containers[0].Image = imgs.SchedulerPluginController
+func ControllerDeployment(dp *appsv1.Deployment, imgs images.Images, pullIfNotPresent, ctrlPlaneAffinity bool) {
+	dp.Spec.Template.Spec.Containers[0].Image = imgs.OrGet().SchedulerPluginController
 	dp.Spec.Template.Spec.Containers[0].ImagePullPolicy = pullPolicy(pullIfNotPresent)
 
 	if ctrlPlaneAffinity {
@@ -53,6 +66,64 @@ func ControllerDeployment(dp *appsv1.Deployment, pullIfNotPresent, ctrlPlaneAffi
 	}
 }
 
+// SecurePortForContainer declares the scheduler secure port in the scheduler container
+func SecurePortForContainer(dp *appsv1.Deployment) {
+	cnt := &dp.Spec.Template.Spec.Containers[0] // shortcut
+	cp := objectupdate.FindContainerPortByName(cnt.Ports, SecurePortName)
+	if cp != nil {
+		cp.ContainerPort = SecurePort
+		return
+	}
+	cnt.Ports = append(cnt.Ports, corev1.ContainerPort{
+		Name:          SecurePortName,
+		ContainerPort: SecurePort,
+		Protocol:      corev1.ProtocolTCP,
+	})
+}
+
+// ServingCertForContainer makes the scheduler serve the certificate of the given kubernetes.io/tls secret,
+// instead of a self-signed one
+func ServingCertForContainer(dp *appsv1.Deployment, secretName string) {
+	podSpec := &dp.Spec.Template.Spec
+	cnt := &podSpec.Containers[0] // shortcut
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: servingCertVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	})
+	cnt.VolumeMounts = append(cnt.VolumeMounts, corev1.VolumeMount{
+		Name:      servingCertVolumeName,
+		ReadOnly:  true,
+		MountPath: servingCertDir,
+	})
+	flags := flagcodec.ParseArgvKeyValue(cnt.Args, flagcodec.WithFlagNormalization)
+	flags.SetOption("--tls-cert-file", path.Join(servingCertDir, corev1.TLSCertKey))
+	flags.SetOption("--tls-private-key-file", path.Join(servingCertDir, corev1.TLSPrivateKeyKey))
+	cnt.Args = flags.Argv()
+}
+
+// HighAvailability spreads the deployment replicas across nodes and zones, and sets their priority class if given.
+// The replicas are often as many as the eligible nodes, so a surge pod could never be scheduled: rollouts replace
+// one replica at a time instead.
+func HighAvailability(dp *appsv1.Deployment, priorityClassName string) {
+	objectupdate.SetPodSpreadAcrossNodes(&dp.Spec.Template.Spec, dp.Spec.Template.Labels)
+	maxSurge := intstr.FromInt32(0)
+	maxUnavailable := intstr.FromInt32(1)
+	dp.Spec.Strategy = appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxSurge:       &maxSurge,
+			MaxUnavailable: &maxUnavailable,
+		},
+	}
+	if priorityClassName != "" {
+		dp.Spec.Template.Spec.PriorityClassName = priorityClassName
+	}
+}
+
 func pullPolicy(pullIfNotPresent bool) corev1.PullPolicy {
 	if pullIfNotPresent {
 		return corev1.PullIfNotPresent
@@ -25,6 +25,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
 )
 
@@ -65,7 +66,7 @@ func TestSchedulerDeployment(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			dp := dpRef.DeepCopy()
 
-			SchedulerDeployment(dp, tc.pullIfNotPresent, tc.ctrlPlaneAffinity, tc.verbose)
+			SchedulerDeployment(dp, images.Images{}, tc.pullIfNotPresent, tc.ctrlPlaneAffinity, tc.verbose)
 			fixSchedulerImage(dp)
 
 			var sb strings.Builder
@@ -29,7 +29,17 @@ import (
 )
 
 func Creatable(mf nfdmf.Manifests, cli client.Client, log logr.Logger) []objectwait.WaitableObject {
-	return []objectwait.WaitableObject{
+	var objs []objectwait.WaitableObject
+	if mf.ImagePullSecret != nil {
+		objs = append(objs, objectwait.WaitableObject{Obj: mf.ImagePullSecret})
+	}
+	if mf.NPDefaultTopologyUpdater != nil {
+		objs = append(objs,
+			objectwait.WaitableObject{Obj: mf.NPDefaultTopologyUpdater},
+			objectwait.WaitableObject{Obj: mf.NPApiServerTopologyUpdater},
+		)
+	}
+	return append(objs, []objectwait.WaitableObject{
 		{Obj: mf.SATopologyUpdater},
 		{Obj: mf.CRTopologyUpdater},
 		{Obj: mf.CRBTopologyUpdater},
@@ -40,7 +50,7 @@ func Creatable(mf nfdmf.Manifests, cli client.Client, log logr.Logger) []objectw
 				return err
 			},
 		},
-	}
+	}...)
 }
 
 func Deletable(mf nfdmf.Manifests, cli client.Client, log logr.Logger) []objectwait.WaitableObject {
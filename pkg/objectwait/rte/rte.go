@@ -36,6 +36,12 @@ func Creatable(mf rtemf.Manifests, cli client.Client, log logr.Logger) []objectw
 		})
 	}
 
+	if mf.ImagePullSecret != nil {
+		objs = append(objs, objectwait.WaitableObject{
+			Obj: mf.ImagePullSecret,
+		})
+	}
+
 	if mf.SecurityContextConstraint != nil {
 		objs = append(objs, objectwait.WaitableObject{
 			Obj: mf.SecurityContextConstraint,
@@ -55,20 +61,34 @@ func Creatable(mf rtemf.Manifests, cli client.Client, log logr.Logger) []objectw
 		})
 	}
 
+	// the service CA operator generates the metrics serving certificate the pods mount from the Service
+	if mf.MetricsService != nil {
+		objs = append(objs, objectwait.WaitableObject{Obj: mf.MetricsService})
+	}
+	for _, obj := range mf.Monitoring.ToObjects() {
+		objs = append(objs, objectwait.WaitableObject{Obj: obj})
+	}
+
 	key := wait.ObjectKey{
 		Namespace: mf.DaemonSet.Namespace,
 		Name:      mf.DaemonSet.Name,
 	}
 
-	return append(objs,
+	objs = append(objs,
 		objectwait.WaitableObject{Obj: mf.Role},
 		objectwait.WaitableObject{Obj: mf.RoleBinding},
 		objectwait.WaitableObject{Obj: mf.ClusterRole},
 		objectwait.WaitableObject{Obj: mf.ClusterRoleBinding},
 		objectwait.WaitableObject{Obj: mf.ServiceAccount},
-		objectwait.WaitableObject{Obj: mf.DefaultNetworkPolicy},
-		objectwait.WaitableObject{Obj: mf.APIServerNetworkPolicy},
-		objectwait.WaitableObject{Obj: mf.MetricsServerNetworkPolicy},
+	)
+	if mf.DefaultNetworkPolicy != nil {
+		objs = append(objs,
+			objectwait.WaitableObject{Obj: mf.DefaultNetworkPolicy},
+			objectwait.WaitableObject{Obj: mf.APIServerNetworkPolicy},
+			objectwait.WaitableObject{Obj: mf.MetricsServerNetworkPolicy},
+		)
+	}
+	objs = append(objs,
 		objectwait.WaitableObject{
 			Obj: mf.DaemonSet,
 			Wait: func(ctx context.Context) error {
@@ -77,6 +97,7 @@ func Creatable(mf rtemf.Manifests, cli client.Client, log logr.Logger) []objectw
 			},
 		},
 	)
+	return objs
 }
 
 func Deletable(mf rtemf.Manifests, cli client.Client, log logr.Logger) []objectwait.WaitableObject {
@@ -92,9 +113,19 @@ func Deletable(mf rtemf.Manifests, cli client.Client, log logr.Logger) []objectw
 		{Obj: mf.ClusterRole},
 		{Obj: mf.ClusterRoleBinding},
 		{Obj: mf.ServiceAccount},
-		{Obj: mf.DefaultNetworkPolicy},
-		{Obj: mf.APIServerNetworkPolicy},
-		{Obj: mf.MetricsServerNetworkPolicy},
+	}
+	if mf.DefaultNetworkPolicy != nil {
+		objs = append(objs,
+			objectwait.WaitableObject{Obj: mf.DefaultNetworkPolicy},
+			objectwait.WaitableObject{Obj: mf.APIServerNetworkPolicy},
+			objectwait.WaitableObject{Obj: mf.MetricsServerNetworkPolicy},
+		)
+	}
+	if mf.MetricsService != nil {
+		objs = append(objs, objectwait.WaitableObject{Obj: mf.MetricsService})
+	}
+	for _, obj := range mf.Monitoring.ToObjects() {
+		objs = append(objs, objectwait.WaitableObject{Obj: obj})
 	}
 	if mf.ConfigMap != nil {
 		objs = append(objs, objectwait.WaitableObject{Obj: mf.ConfigMap})
@@ -29,18 +29,33 @@ import (
 )
 
 func Creatable(mf schedmf.Manifests, cli client.Client, log logr.Logger) []objectwait.WaitableObject {
-	return []objectwait.WaitableObject{
+	objs := []objectwait.WaitableObject{
 		{Obj: mf.Crd},
 		{Obj: mf.Namespace},
 		{Obj: mf.SAScheduler},
 		{Obj: mf.CRScheduler},
 		{Obj: mf.CRBScheduler},
-		{Obj: mf.NPDefaultScheduler},
-		{Obj: mf.NPApiServerScheduler},
+	}
+	if mf.NPDefaultScheduler != nil {
+		objs = append(objs,
+			objectwait.WaitableObject{Obj: mf.NPDefaultScheduler},
+			objectwait.WaitableObject{Obj: mf.NPApiServerScheduler},
+		)
+	}
+	objs = append(objs, []objectwait.WaitableObject{
 		{Obj: mf.RSchedulerElect},
 		{Obj: mf.RBSchedulerElect},
 		{Obj: mf.RBSchedulerAuth},
 		{Obj: mf.ConfigMap},
+	}...)
+	if mf.ImagePullSecret != nil {
+		objs = append(objs, objectwait.WaitableObject{Obj: mf.ImagePullSecret})
+	}
+	// on OpenShift the metrics service generates the serving certificate the scheduler pods mount
+	for _, obj := range mf.Monitoring.ToObjects() {
+		objs = append(objs, objectwait.WaitableObject{Obj: obj})
+	}
+	objs = append(objs, []objectwait.WaitableObject{
 		{
 			Obj: mf.DPScheduler,
 			Wait: func(ctx context.Context) error {
@@ -52,8 +67,14 @@ func Creatable(mf schedmf.Manifests, cli client.Client, log logr.Logger) []objec
 		{Obj: mf.CRController},
 		{Obj: mf.CRBController},
 		{Obj: mf.RBController},
-		{Obj: mf.NPDefaultController},
-		{Obj: mf.NPApiServerController},
+	}...)
+	if mf.NPDefaultController != nil {
+		objs = append(objs,
+			objectwait.WaitableObject{Obj: mf.NPDefaultController},
+			objectwait.WaitableObject{Obj: mf.NPApiServerController},
+		)
+	}
+	objs = append(objs, []objectwait.WaitableObject{
 		{
 			Obj: mf.DPController,
 			Wait: func(ctx context.Context) error {
@@ -61,11 +82,21 @@ func Creatable(mf schedmf.Manifests, cli client.Client, log logr.Logger) []objec
 				return err
 			},
 		},
+	}...)
+	if mf.PDBScheduler != nil {
+		objs = append(objs, objectwait.WaitableObject{Obj: mf.PDBScheduler})
 	}
+	if mf.PDBController != nil {
+		objs = append(objs, objectwait.WaitableObject{Obj: mf.PDBController})
+	}
+	if mf.NPMetricsScheduler != nil {
+		objs = append(objs, objectwait.WaitableObject{Obj: mf.NPMetricsScheduler})
+	}
+	return objs
 }
 
 func Deletable(mf schedmf.Manifests, cli client.Client, log logr.Logger) []objectwait.WaitableObject {
-	return []objectwait.WaitableObject{
+	objs := []objectwait.WaitableObject{
 		{
 			Obj: mf.Namespace,
 			Wait: func(ctx context.Context) error {
@@ -75,16 +106,27 @@ func Deletable(mf schedmf.Manifests, cli client.Client, log logr.Logger) []objec
 		// no need to remove objects created inside the namespace we just removed
 		{Obj: mf.CRBScheduler},
 		{Obj: mf.CRScheduler},
-		{Obj: mf.NPDefaultScheduler},
-		{Obj: mf.NPApiServerScheduler},
 		{Obj: mf.RBSchedulerAuth},
 		{Obj: mf.RBSchedulerElect},
 		{Obj: mf.RSchedulerElect},
 		{Obj: mf.CRBController},
 		{Obj: mf.CRController},
 		{Obj: mf.RBController},
-		{Obj: mf.NPDefaultController},
-		{Obj: mf.NPApiServerController},
 		{Obj: mf.Crd},
 	}
+	if mf.NPDefaultScheduler != nil {
+		objs = append(objs,
+			objectwait.WaitableObject{Obj: mf.NPDefaultScheduler},
+			objectwait.WaitableObject{Obj: mf.NPApiServerScheduler},
+			objectwait.WaitableObject{Obj: mf.NPDefaultController},
+			objectwait.WaitableObject{Obj: mf.NPApiServerController},
+		)
+	}
+	if mf.Monitoring != nil && mf.Monitoring.ClusterRole != nil {
+		objs = append(objs,
+			objectwait.WaitableObject{Obj: mf.Monitoring.ClusterRoleBinding},
+			objectwait.WaitableObject{Obj: mf.Monitoring.ClusterRole},
+		)
+	}
+	return objs
 }
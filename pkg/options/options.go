@@ -17,11 +17,18 @@
 package options
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/k8stopologyawareschedwg/deployer/pkg/deployer/platform"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/patches"
 )
 
 type SCCVersion string
@@ -35,18 +42,67 @@ func IsValidSCCVersion(ver string) bool {
 	return ver == string(SCCV1) || ver == string(SCCV2)
 }
 
+type MetricsTLSMode string
+
+const (
+	MetricsTLSOff       MetricsTLSMode = "off"
+	MetricsTLSServiceCA MetricsTLSMode = "service-ca"
+	MetricsTLSSecret    MetricsTLSMode = "secret"
+)
+
+// MetricsTLS configures the serving certificate of the updater metrics endpoint
+type MetricsTLS struct {
+	Mode MetricsTLSMode
+	// SecretName is the kubernetes.io/tls secret holding the serving certificate.
+	// In service-ca mode the secret is generated by the OpenShift service CA operator, and empty uses the default name.
+	SecretName string
+}
+
+func (mt MetricsTLS) Enabled() bool {
+	return mt.Mode == MetricsTLSServiceCA || mt.Mode == MetricsTLSSecret
+}
+
+// ParseMetricsTLS parses "off", "service-ca" or "secret:NAME", where NAME is an existing kubernetes.io/tls secret.
+// An empty spec means off.
+func ParseMetricsTLS(spec string) (MetricsTLS, error) {
+	mode, name, hasName := strings.Cut(spec, ":")
+	switch MetricsTLSMode(mode) {
+	case "", MetricsTLSOff, MetricsTLSServiceCA:
+		if hasName {
+			return MetricsTLS{}, fmt.Errorf("metrics TLS %q: unexpected secret name", spec)
+		}
+		if MetricsTLSMode(mode) == MetricsTLSServiceCA {
+			return MetricsTLS{Mode: MetricsTLSServiceCA}, nil
+		}
+		return MetricsTLS{Mode: MetricsTLSOff}, nil
+	case MetricsTLSSecret:
+		if name == "" {
+			return MetricsTLS{}, fmt.Errorf("metrics TLS %q: missing secret name", spec)
+		}
+		return MetricsTLS{Mode: MetricsTLSSecret, SecretName: name}, nil
+	default:
+		return MetricsTLS{}, fmt.Errorf("metrics TLS %q: unsupported mode %q", spec, mode)
+	}
+}
+
 type Options struct {
-	UserPlatform                platform.Platform
-	UserPlatformVersion         platform.Version
-	Replicas                    int
-	RTEConfigData               string
-	PullIfNotPresent            bool
-	UpdaterType                 string
-	UpdaterPFPEnable            bool
-	UpdaterNotifEnable          bool
-	UpdaterCRIHooksEnable       bool
-	UpdaterCustomSELinuxPolicy  bool
-	UpdaterSCCVersion           SCCVersion
+	UserPlatform               platform.Platform
+	UserPlatformVersion        platform.Version
+	UserFlavor                 platform.Flavor
+	Replicas                   int
+	RTEConfigData              string
+	PullIfNotPresent           bool
+	UpdaterType                string
+	UpdaterPFPEnable           bool
+	UpdaterNotifEnable         bool
+	UpdaterCRIHooksEnable      bool
+	UpdaterCustomSELinuxPolicy bool
+	UpdaterSCCVersion          SCCVersion
+	// UpdaterMetricsPort is the port of the updater metrics endpoint. Zero uses the default.
+	UpdaterMetricsPort int
+	UpdaterMetricsTLS  MetricsTLS
+	// UpdaterKubeletConfigFile overrides the name of the kubelet configuration file of the flavor
+	UpdaterKubeletConfigFile    string
 	UpdaterSyncPeriod           time.Duration
 	UpdaterVerbose              int
 	SchedProfileName            string
@@ -54,21 +110,84 @@ type Options struct {
 	SchedVerbose                int
 	SchedCtrlPlaneAffinity      bool
 	SchedLeaderElectResource    string
+	SchedLeaseDuration          time.Duration
+	SchedRenewDeadline          time.Duration
+	SchedRetryPeriod            time.Duration
+	SchedHighAvailability       bool
+	SchedPriorityClassName      string
 	WaitInterval                time.Duration
 	WaitTimeout                 time.Duration
 	ClusterPlatform             platform.Platform
 	ClusterVersion              platform.Version
+	ClusterFlavor               platform.Flavor
 	WaitCompletion              bool
 	SchedScoringStratConfigData string
 	SchedCacheParamsConfigData  string
+	NodeSelector                *metav1.LabelSelector
+	Tolerations                 []corev1.Toleration
+	OnConflict                  string
+	NodePoolNamespace           string
+	// OnIncompatibleImages tells what to do if no bundled image set supports the cluster version: warn, fail
+	OnIncompatibleImages string
+	// RenderFromCluster detects the platform and the images to render from the cluster
+	RenderFromCluster bool
+	// Images selected for the cluster version. Empty uses the default images.
+	Images images.Images
+	// Metrics configures the scraping of the RTE and scheduler metrics
+	Metrics Metrics
+	// NetworkPolicies configures the network policies of all the components
+	NetworkPolicies NetworkPolicies
+	// Resources maps the component containers to the requests and limits overriding the manifests defaults
+	RTEResources             corev1.ResourceRequirements
+	RTEPauseResources        corev1.ResourceRequirements
+	NFDResources             corev1.ResourceRequirements
+	SchedResources           corev1.ResourceRequirements
+	SchedControllerResources corev1.ResourceRequirements
+	// ImageMirrors redirect the container images of all the components
+	ImageMirrors images.Mirrors
+	// ImagePullSecret is the name of the pull secret of all the component pods
+	ImagePullSecret string
+	// ImagePullSecretFrom is the secret to clone as ImagePullSecret in the component namespaces. Empty name disables cloning.
+	ImagePullSecretFrom types.NamespacedName
+	// ImagePullSecretSource is the secret read from ImagePullSecretFrom
+	ImagePullSecretSource *corev1.Secret
+	// Patches maps the manifests components to the patches to apply after rendering
+	Patches map[string][]patches.Patch
+}
+
+// NetworkPolicies configures the network policies isolating the component pods
+type NetworkPolicies struct {
+	// Disabled skips the network policies, for the CNIs which don't enforce them
+	Disabled bool
+	// APIServerAddresses are the IPs or CIDRs of the API server endpoints. Empty allows any address.
+	APIServerAddresses []string
+	// APIServerPorts are the ports of the API server endpoints. Empty uses the default port.
+	APIServerPorts []int32
+}
+
+// Metrics configures the scraping of the component metrics by the Prometheus Operator
+type Metrics struct {
+	// Monitor is the kind of the monitors to render: ServiceMonitor or PodMonitor. Empty disables the scraping.
+	Monitor string
+	// Stack is the OpenShift monitoring stack scraping the metrics: cluster or user-workload
+	Stack string
+	// Scraper is the "namespace/name" service account of Prometheus
+	Scraper string
+	// InsecureSkipVerify lets the monitors scrape the HTTPS endpoints without a CA to verify their certificate
+	InsecureSkipVerify bool
 }
 
 type API struct {
 	Platform platform.Platform
+	// Adopt updates the already existing objects instead of failing
+	Adopt   bool
+	Patches []patches.Patch
 }
 
 type Scheduler struct {
-	Platform               platform.Platform
+	Platform platform.Platform
+	// Flavor constrains the scheduler placement
+	Flavor                 platform.Flavor
 	WaitCompletion         bool
 	Replicas               int32
 	ProfileName            string
@@ -77,10 +196,32 @@ type Scheduler struct {
 	CtrlPlaneAffinity      bool
 	LeaderElection         bool
 	LeaderElectionResource string
+	// LeaseDuration, RenewDeadline and RetryPeriod tune the leader election. Zero uses the scheduler defaults.
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+	// HighAvailability adds a PodDisruptionBudget and spreads the replicas across nodes and zones
+	HighAvailability bool
+	// PriorityClassName of the scheduler and controller pods. Empty means system-cluster-critical in high availability mode.
+	PriorityClassName      string
 	Verbose                int
 	ScoringStratConfigData string
 	CacheParamsConfigData  string
-	Namespace              string
+	// SchedulerResources and ControllerResources override the manifests defaults
+	SchedulerResources  corev1.ResourceRequirements
+	ControllerResources corev1.ResourceRequirements
+	// Images of the scheduler and controller. Empty uses the default images.
+	Images          images.Images
+	Metrics         Metrics
+	NetworkPolicies NetworkPolicies
+	ImageMirrors    images.Mirrors
+	ImagePullSecret string
+	// ImagePullSecretSource, if set, is cloned as ImagePullSecret in the scheduler namespace
+	ImagePullSecretSource *corev1.Secret
+	// Adopt updates the already existing objects instead of failing
+	Adopt     bool
+	Namespace string
+	Patches   []patches.Patch
 }
 
 type DaemonSet struct {
@@ -89,8 +230,24 @@ type DaemonSet struct {
 	PFPEnable          bool
 	NotificationEnable bool
 	NodeSelector       *metav1.LabelSelector
+	Tolerations        []corev1.Toleration
 	UpdateInterval     time.Duration
 	SCCVersion         SCCVersion
+	// Flavor selects the host paths of the distribution
+	Flavor platform.Flavor
+	// RTEResources, PauseResources and NFDResources override the manifests defaults
+	RTEResources   corev1.ResourceRequirements
+	PauseResources corev1.ResourceRequirements
+	NFDResources   corev1.ResourceRequirements
+	// ImagePullSecret is the name of the pull secret of the pods
+	ImagePullSecret string
+	// Images of the updater containers. Empty uses the default images.
+	Images images.Images
+	// MetricsPort and MetricsTLS configure the updater metrics endpoint. Zero uses the default port.
+	MetricsPort int
+	MetricsTLS  MetricsTLS
+	// KubeletConfigFile, if set, overrides the name of the kubelet configuration file in the flavor kubelet configuration directory
+	KubeletConfigFile string
 }
 
 type UpdaterDaemon struct {
@@ -99,6 +256,12 @@ type UpdaterDaemon struct {
 	ConfigData                string
 	Namespace                 string
 	Name                      string
+	// NodePoolNamespace is the management cluster namespace holding the HyperShift NodePool configuration
+	NodePoolNamespace string
+	// ImagePullSecretSource, if set, is cloned as DaemonSet.ImagePullSecret in the updater namespace
+	ImagePullSecretSource *corev1.Secret
+	Metrics               Metrics
+	NetworkPolicies       NetworkPolicies
 }
 
 type Updater struct {
@@ -109,6 +272,15 @@ type Updater struct {
 	DaemonSet           DaemonSet
 	EnableCRIHooks      bool
 	CustomSELinuxPolicy bool
+	NodePoolNamespace   string
+	ImageMirrors        images.Mirrors
+	// ImagePullSecretSource, if set, is cloned as DaemonSet.ImagePullSecret in the updater namespace
+	ImagePullSecretSource *corev1.Secret
+	Metrics               Metrics
+	NetworkPolicies       NetworkPolicies
+	// Adopt updates the already existing objects instead of failing
+	Adopt   bool
+	Patches []patches.Patch
 }
 
 type Render struct {
@@ -127,5 +299,110 @@ func ForDaemonSet(commonOpts *Options) DaemonSet {
 		UpdateInterval:     commonOpts.UpdaterSyncPeriod,
 		SCCVersion:         commonOpts.UpdaterSCCVersion,
 		Verbose:            commonOpts.UpdaterVerbose,
+		NodeSelector:       commonOpts.NodeSelector,
+		Tolerations:        commonOpts.Tolerations,
+		Flavor:             commonOpts.ClusterFlavor,
+		RTEResources:       commonOpts.RTEResources,
+		PauseResources:     commonOpts.RTEPauseResources,
+		NFDResources:       commonOpts.NFDResources,
+		ImagePullSecret:    commonOpts.ImagePullSecret,
+		Images:             commonOpts.Images,
+		MetricsPort:        commonOpts.UpdaterMetricsPort,
+		MetricsTLS:         commonOpts.UpdaterMetricsTLS,
+		KubeletConfigFile:  commonOpts.UpdaterKubeletConfigFile,
+	}
+}
+
+// ParseNodeSelector parses a label selector in the kubectl syntax (e.g. "foo=bar,baz in (a,b)").
+// Returns nil for an empty selector.
+func ParseNodeSelector(spec string) (*metav1.LabelSelector, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	return metav1.ParseToLabelSelector(spec)
+}
+
+// ParseTolerations parses a comma-separated list of tolerations using the kubectl taint syntax:
+// "key=value:Effect" tolerates the given taint, "key:Effect" any value of the key, "key" any value and effect.
+// A lone "*" tolerates everything.
+func ParseTolerations(spec string) ([]corev1.Toleration, error) {
+	var ret []corev1.Toleration
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if item == "*" {
+			ret = append(ret, corev1.Toleration{Operator: corev1.TolerationOpExists})
+			continue
+		}
+		tol := corev1.Toleration{
+			Operator: corev1.TolerationOpExists,
+		}
+		keyValue, effect, hasEffect := strings.Cut(item, ":")
+		if hasEffect {
+			switch corev1.TaintEffect(effect) {
+			case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+				tol.Effect = corev1.TaintEffect(effect)
+			default:
+				return nil, fmt.Errorf("toleration %q: unsupported effect %q", item, effect)
+			}
+		}
+		key, value, hasValue := strings.Cut(keyValue, "=")
+		if key == "" {
+			return nil, fmt.Errorf("toleration %q: missing key", item)
+		}
+		tol.Key = key
+		if hasValue {
+			tol.Operator = corev1.TolerationOpEqual
+			tol.Value = value
+		}
+		ret = append(ret, tol)
+	}
+	return ret, nil
+}
+
+// ParseResources parses a comma-separated list of container resources in the form
+// "requests.cpu=50m,requests.memory=64Mi,limits.memory=256Mi".
+func ParseResources(spec string) (corev1.ResourceRequirements, error) {
+	ret := corev1.ResourceRequirements{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(item, "=")
+		if !ok || value == "" {
+			return ret, fmt.Errorf("resource %q: missing quantity", item)
+		}
+		kind, name, ok := strings.Cut(key, ".")
+		if !ok || name == "" {
+			return ret, fmt.Errorf("resource %q: expected \"requests.NAME\" or \"limits.NAME\"", item)
+		}
+		qty, err := resource.ParseQuantity(value)
+		if err != nil {
+			return ret, fmt.Errorf("resource %q: %w", item, err)
+		}
+		switch kind {
+		case "requests":
+			if ret.Requests == nil {
+				ret.Requests = make(corev1.ResourceList)
+			}
+			ret.Requests[corev1.ResourceName(name)] = qty
+		case "limits":
+			if ret.Limits == nil {
+				ret.Limits = make(corev1.ResourceList)
+			}
+			ret.Limits[corev1.ResourceName(name)] = qty
+		default:
+			return ret, fmt.Errorf("resource %q: unsupported kind %q", item, kind)
+		}
+	}
+	for name, req := range ret.Requests {
+		lim, ok := ret.Limits[name]
+		if ok && req.Cmp(lim) > 0 {
+			return ret, fmt.Errorf("resource %q: request %s exceeds limit %s", name, req.String(), lim.String())
+		}
 	}
+	return ret, nil
 }
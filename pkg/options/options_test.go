@@ -0,0 +1,228 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package options
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestParseTolerations(t *testing.T) {
+	type testCase struct {
+		name          string
+		spec          string
+		expected      []corev1.Toleration
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "empty",
+		},
+		{
+			name: "full",
+			spec: "dedicated=numa:NoSchedule, node.kubernetes.io/unreachable:NoExecute,special",
+			expected: []corev1.Toleration{
+				{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "numa",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+				{
+					Key:      "node.kubernetes.io/unreachable",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoExecute,
+				},
+				{
+					Key:      "special",
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+		},
+		{
+			name: "everything",
+			spec: "*",
+			expected: []corev1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+		},
+		{
+			name:          "bad effect",
+			spec:          "foo=bar:Never",
+			expectedError: true,
+		},
+		{
+			name:          "missing key",
+			spec:          "=bar:NoSchedule",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseTolerations(tc.spec)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("mismatch:\ngot=%#v\nexpected=%#v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseResources(t *testing.T) {
+	type testCase struct {
+		name          string
+		spec          string
+		expected      corev1.ResourceRequirements
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "empty",
+		},
+		{
+			name: "full",
+			spec: "requests.cpu=50m, requests.memory=64Mi,limits.memory=256Mi",
+			expected: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("50m"),
+					corev1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+			},
+		},
+		{
+			name:          "bad kind",
+			spec:          "request.cpu=50m",
+			expectedError: true,
+		},
+		{
+			name:          "missing name",
+			spec:          "requests=50m",
+			expectedError: true,
+		},
+		{
+			name:          "bad quantity",
+			spec:          "requests.cpu=fifty",
+			expectedError: true,
+		},
+		{
+			name:          "request exceeds limit",
+			spec:          "requests.memory=512Mi,limits.memory=256Mi",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseResources(tc.spec)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("mismatch:\ngot=%#v\nexpected=%#v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseMetricsTLS(t *testing.T) {
+	type testCase struct {
+		name          string
+		spec          string
+		expected      MetricsTLS
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "empty",
+			expected: MetricsTLS{Mode: MetricsTLSOff},
+		},
+		{
+			name:     "off",
+			spec:     "off",
+			expected: MetricsTLS{Mode: MetricsTLSOff},
+		},
+		{
+			name:     "service-ca",
+			spec:     "service-ca",
+			expected: MetricsTLS{Mode: MetricsTLSServiceCA},
+		},
+		{
+			name:     "secret",
+			spec:     "secret:rte-metrics-cert",
+			expected: MetricsTLS{Mode: MetricsTLSSecret, SecretName: "rte-metrics-cert"},
+		},
+		{
+			name:          "secret without name",
+			spec:          "secret:",
+			expectedError: true,
+		},
+		{
+			name:          "service-ca with name",
+			spec:          "service-ca:rte-metrics-cert",
+			expectedError: true,
+		},
+		{
+			name:          "unsupported mode",
+			spec:          "mtls",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseMetricsTLS(tc.spec)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("mismatch:\ngot=%#v\nexpected=%#v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package patches
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+type Type string
+
+const (
+	StrategicMerge Type = "strategic-merge"
+	JSON6902       Type = "json6902"
+)
+
+type Target struct {
+	Kind string `json:"kind"`
+	// Name of the object to patch. Empty matches all the objects of the given kind.
+	Name string `json:"name,omitempty"`
+}
+
+func (tg Target) String() string {
+	if tg.Name == "" {
+		return tg.Kind
+	}
+	return tg.Kind + "/" + tg.Name
+}
+
+// Patch is a single patch, targeted by kind and name.
+// Exactly one of StrategicMerge and JSON6902 must be set.
+type Patch struct {
+	Target         Target          `json:"target"`
+	StrategicMerge json.RawMessage `json:"strategicMerge,omitempty"`
+	JSON6902       json.RawMessage `json:"json6902,omitempty"`
+}
+
+func (pt Patch) Type() Type {
+	if len(pt.JSON6902) > 0 {
+		return JSON6902
+	}
+	return StrategicMerge
+}
+
+func (pt Patch) Validate() error {
+	if pt.Target.Kind == "" {
+		return fmt.Errorf("missing target kind")
+	}
+	hasSMP, hasJSON := len(pt.StrategicMerge) > 0, len(pt.JSON6902) > 0
+	if hasSMP == hasJSON {
+		return fmt.Errorf("patch for %s: exactly one of strategicMerge and json6902 must be set", pt.Target)
+	}
+	if hasJSON {
+		if _, err := jsonpatch.DecodePatch(pt.JSON6902); err != nil {
+			return fmt.Errorf("patch for %s: malformed json6902 patch: %w", pt.Target, err)
+		}
+	}
+	return nil
+}
+
+// ParseSpec splits a "component:path" patch specification.
+func ParseSpec(spec string) (string, string, error) {
+	component, path, ok := strings.Cut(spec, ":")
+	if !ok || component == "" || path == "" {
+		return "", "", fmt.Errorf("malformed patch spec %q, expected \"component:path\"", spec)
+	}
+	return component, path, nil
+}
+
+// Decode decodes a YAML or JSON list of patches.
+func Decode(data []byte) ([]Patch, error) {
+	var pts []Patch
+	if err := yaml.UnmarshalStrict(data, &pts); err != nil {
+		return nil, err
+	}
+	for idx, pt := range pts {
+		if err := pt.Validate(); err != nil {
+			return nil, fmt.Errorf("patch #%d: %w", idx, err)
+		}
+	}
+	return pts, nil
+}
+
+func ReadFile(path string) ([]Patch, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pts, err := Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("patch file %q: %w", path, err)
+	}
+	return pts, nil
+}
+
+// Apply applies the patches, in order, to the objects. The objects are updated in place,
+// so the pointers held by the manifests are patched too.
+// Returns error if any patch does not match any object: a patch which silently does nothing is most likely a typo.
+func Apply(objs []client.Object, pts []Patch) error {
+	for idx, pt := range pts {
+		matched := 0
+		for _, obj := range objs {
+			if obj == nil || reflect.ValueOf(obj).IsNil() || !pt.Target.Matches(obj) {
+				continue
+			}
+			if err := applyToObject(obj, pt); err != nil {
+				return fmt.Errorf("patch #%d for %s: %w", idx, pt.Target, err)
+			}
+			matched++
+		}
+		if matched == 0 {
+			return fmt.Errorf("patch #%d for %s: no matching object", idx, pt.Target)
+		}
+	}
+	return nil
+}
+
+func (tg Target) Matches(obj client.Object) bool {
+	if KindOf(obj) != tg.Kind {
+		return false
+	}
+	return tg.Name == "" || tg.Name == obj.GetName()
+}
+
+// KindOf returns the kind of the object, falling back to the go type name for objects without TypeMeta.
+func KindOf(obj client.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	return reflect.TypeOf(obj).Elem().Name()
+}
+
+func applyToObject(obj client.Object, pt Patch) error {
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+	if pt.Type() == JSON6902 {
+		ops, err := jsonpatch.DecodePatch(pt.JSON6902)
+		if err != nil {
+			return err
+		}
+		patched, err = ops.Apply(original)
+		if err != nil {
+			return err
+		}
+	} else {
+		patched, err = strategicpatch.StrategicMergePatch(original, pt.StrategicMerge, obj)
+		if err != nil {
+			return err
+		}
+	}
+
+	// reset the object, otherwise fields removed by the patch would survive the unmarshalling
+	val := reflect.ValueOf(obj).Elem()
+	val.Set(reflect.Zero(val.Type()))
+	return json.Unmarshal(patched, obj)
+}
@@ -0,0 +1,248 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package patches
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDecode(t *testing.T) {
+	type testCase struct {
+		name          string
+		data          string
+		expectedCount int
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "strategic merge and json6902",
+			data: `
+- target:
+    kind: DaemonSet
+    name: resource-topology-exporter
+  strategicMerge:
+    metadata:
+      labels:
+        foo: bar
+- target:
+    kind: Deployment
+  json6902:
+  - op: add
+    path: /metadata/annotations
+    value:
+      foo: bar
+`,
+			expectedCount: 2,
+		},
+		{
+			name: "missing kind",
+			data: `
+- target:
+    name: resource-topology-exporter
+  strategicMerge:
+    metadata:
+      labels:
+        foo: bar
+`,
+			expectedError: true,
+		},
+		{
+			name: "both patch types",
+			data: `
+- target:
+    kind: DaemonSet
+  strategicMerge:
+    metadata:
+      labels:
+        foo: bar
+  json6902:
+  - op: remove
+    path: /metadata/labels
+`,
+			expectedError: true,
+		},
+		{
+			name: "no patch",
+			data: `
+- target:
+    kind: DaemonSet
+`,
+			expectedError: true,
+		},
+		{
+			name: "malformed json6902",
+			data: `
+- target:
+    kind: DaemonSet
+  json6902:
+    op: remove
+`,
+			expectedError: true,
+		},
+		{
+			name: "unknown field",
+			data: `
+- target:
+    kind: DaemonSet
+  strategic:
+    metadata: {}
+`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pts, err := Decode([]byte(tc.data))
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("decode succeeded, expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(pts) != tc.expectedCount {
+				t.Errorf("got %d patches expected %d", len(pts), tc.expectedCount)
+			}
+		})
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	component, path, err := ParseSpec("rte:/tmp/patches/rte.yaml")
+	if err != nil || component != "rte" || path != "/tmp/patches/rte.yaml" {
+		t.Errorf("unexpected result: %q %q %v", component, path, err)
+	}
+	for _, spec := range []string{"", "rte", "rte:", ":/tmp/rte.yaml"} {
+		if _, _, err := ParseSpec(spec); err == nil {
+			t.Errorf("spec %q: expected error", spec)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	pts, err := Decode([]byte(`
+- target:
+    kind: DaemonSet
+    name: resource-topology-exporter
+  strategicMerge:
+    metadata:
+      labels:
+        team: numa
+    spec:
+      template:
+        spec:
+          containers:
+          - name: resource-topology-exporter
+            env:
+            - name: EXTRA
+              value: "1"
+- target:
+    kind: ServiceAccount
+  json6902:
+  - op: add
+    path: /metadata/annotations
+    value:
+      owner: platform
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "resource-topology-exporter",
+			Labels: map[string]string{"app": "rte"},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "resource-topology-exporter",
+							Image: "quay.io/rte:latest",
+							Env: []corev1.EnvVar{
+								{Name: "NODE_NAME", Value: "foo"},
+							},
+						},
+						{
+							Name:  "sidecar",
+							Image: "quay.io/sidecar:latest",
+						},
+					},
+				},
+			},
+		},
+	}
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "rte"}}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "rte"}}
+
+	err = Apply([]client.Object{ds, sa, cm}, pts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ds.Labels["app"] != "rte" || ds.Labels["team"] != "numa" {
+		t.Errorf("unexpected labels: %v", ds.Labels)
+	}
+	cnts := ds.Spec.Template.Spec.Containers
+	if len(cnts) != 2 || cnts[0].Image != "quay.io/rte:latest" {
+		t.Fatalf("containers not merged by name: %+v", cnts)
+	}
+	if len(cnts[0].Env) != 2 {
+		t.Errorf("env not merged by name: %+v", cnts[0].Env)
+	}
+	if sa.Annotations["owner"] != "platform" {
+		t.Errorf("json6902 patch not applied: %v", sa.Annotations)
+	}
+	if len(cm.Annotations) != 0 || len(cm.Labels) != 0 {
+		t.Errorf("unexpected patch on unrelated object: %+v", cm.ObjectMeta)
+	}
+}
+
+func TestApplyNoMatch(t *testing.T) {
+	pts := []Patch{
+		{
+			Target:         Target{Kind: "DaemonSet", Name: "typo"},
+			StrategicMerge: []byte(`{"metadata":{"labels":{"foo":"bar"}}}`),
+		},
+	}
+	ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "resource-topology-exporter"}}
+	if err := Apply([]client.Object{ds}, pts); err == nil {
+		t.Fatalf("unmatched patch succeeded")
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	ds := &appsv1.DaemonSet{}
+	if got := KindOf(ds); got != "DaemonSet" {
+		t.Errorf("got %q expected DaemonSet", got)
+	}
+	cm := &corev1.ConfigMap{TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"}}
+	if got := KindOf(cm); got != "ConfigMap" {
+		t.Errorf("got %q expected ConfigMap", got)
+	}
+}
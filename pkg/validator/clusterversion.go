@@ -37,6 +37,7 @@ func (vd *Validator) ValidateClusterVersion(cli *discovery.DiscoveryClient) ([]V
 	}
 	vd.serverVersion = ver
 	vrs := ValidateClusterVersion(ver.GitVersion)
+	vd.checks = append(vd.checks, Check{Area: AreaCluster, RuleID: RuleClusterAPIVersion})
 	vd.results = append(vd.results, vrs...)
 	return vrs, nil
 }
@@ -50,8 +51,11 @@ func ValidateClusterVersion(clusterVersion string) []ValidationResult {
 				Area:      AreaCluster,
 				Component: ComponentAPIVersion,
 				/* no specific Setting: implicit in the component! */
-				Expected: "valid version",
-				Detected: err.Error(),
+				Expected:    "valid version",
+				Detected:    err.Error(),
+				Severity:    SeverityError,
+				RuleID:      RuleClusterAPIVersion,
+				Remediation: Remediation(RuleClusterAPIVersion),
 			},
 		}
 	}
@@ -62,8 +66,11 @@ func ValidateClusterVersion(clusterVersion string) []ValidationResult {
 				Area:      AreaCluster,
 				Component: ComponentAPIVersion,
 				/* no specific Setting: implicit in the component! */
-				Expected: ExpectedMinKubeVersion,
-				Detected: clusterVersion,
+				Expected:    ExpectedMinKubeVersion,
+				Detected:    clusterVersion,
+				Severity:    SeverityError,
+				RuleID:      RuleClusterAPIVersion,
+				Remediation: Remediation(RuleClusterAPIVersion),
 			},
 		}
 	}
@@ -0,0 +1,192 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+const (
+	// short enough to be readable, long enough to make collisions unlikely
+	poolHashLen = 12
+)
+
+// NodePool is a set of nodes sharing the same topology-aware-scheduling relevant kubelet settings
+type NodePool struct {
+	Hash  string   `json:"hash"`
+	Nodes []string `json:"nodes"`
+	// Settings holds only the settings which differ across pools
+	Settings map[string]string `json:"settings,omitempty"`
+	Outlier  bool              `json:"outlier,omitempty"`
+}
+
+type ConsistencyReport struct {
+	Pools           []NodePool `json:"pools"`
+	DifferingFields []string   `json:"differingFields,omitempty"`
+}
+
+// Outliers returns the names of the nodes which are the only members of their pool.
+func (cr ConsistencyReport) Outliers() []string {
+	var ret []string
+	for _, pool := range cr.Pools {
+		if pool.Outlier {
+			ret = append(ret, pool.Nodes...)
+		}
+	}
+	return ret
+}
+
+// KubeletFingerprint returns the kubelet settings relevant for topology-aware-scheduling, in a canonical form.
+func KubeletFingerprint(kubeletConf *kubeletconfigv1beta1.KubeletConfiguration) map[string]string {
+	if kubeletConf == nil {
+		return map[string]string{}
+	}
+	return map[string]string{
+		"cpuManagerPolicy":             kubeletConf.CPUManagerPolicy,
+		"cpuManagerPolicyOptions":      canonicalValue(kubeletConf.CPUManagerPolicyOptions),
+		"cpuManagerReconcilePeriod":    kubeletConf.CPUManagerReconcilePeriod.Duration.String(),
+		"reservedSystemCPUs":           kubeletConf.ReservedSystemCPUs,
+		"memoryManagerPolicy":          kubeletConf.MemoryManagerPolicy,
+		"reservedMemory":               canonicalValue(kubeletConf.ReservedMemory),
+		"kubeReserved":                 canonicalValue(kubeletConf.KubeReserved),
+		"systemReserved":               canonicalValue(kubeletConf.SystemReserved),
+		"evictionHard":                 canonicalValue(kubeletConf.EvictionHard),
+		"topologyManagerPolicy":        kubeletConf.TopologyManagerPolicy,
+		"topologyManagerPolicyOptions": canonicalValue(kubeletConf.TopologyManagerPolicyOptions),
+		"topologyManagerScope":         topologyManagerScope(kubeletConf),
+		"featureGates":                 canonicalValue(kubeletConf.FeatureGates),
+	}
+}
+
+// CheckKubeletConsistency groups nodes by their kubelet settings relevant for topology-aware-scheduling.
+func CheckKubeletConsistency(kubeConfs map[string]*kubeletconfigv1beta1.KubeletConfiguration) ConsistencyReport {
+	fingerprints := make(map[string]map[string]string)
+	poolsByHash := make(map[string]*NodePool)
+	for nodeName, kubeletConf := range kubeConfs {
+		fp := KubeletFingerprint(kubeletConf)
+		hash := fingerprintHash(fp)
+		fingerprints[hash] = fp
+		pool, ok := poolsByHash[hash]
+		if !ok {
+			pool = &NodePool{Hash: hash}
+			poolsByHash[hash] = pool
+		}
+		pool.Nodes = append(pool.Nodes, nodeName)
+	}
+
+	report := ConsistencyReport{}
+	for _, pool := range poolsByHash {
+		sort.Strings(pool.Nodes)
+		report.Pools = append(report.Pools, *pool)
+	}
+	// biggest pools first, then by name for stable output
+	sort.Slice(report.Pools, func(i, j int) bool {
+		if len(report.Pools[i].Nodes) != len(report.Pools[j].Nodes) {
+			return len(report.Pools[i].Nodes) > len(report.Pools[j].Nodes)
+		}
+		return report.Pools[i].Nodes[0] < report.Pools[j].Nodes[0]
+	})
+	if len(report.Pools) < 2 {
+		return report
+	}
+
+	fields := []string{}
+	for field := range fingerprints[report.Pools[0].Hash] {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		ref := fingerprints[report.Pools[0].Hash][field]
+		for _, pool := range report.Pools[1:] {
+			if fingerprints[pool.Hash][field] != ref {
+				report.DifferingFields = append(report.DifferingFields, field)
+				break
+			}
+		}
+	}
+
+	for idx := range report.Pools {
+		pool := &report.Pools[idx]
+		pool.Settings = make(map[string]string)
+		for _, field := range report.DifferingFields {
+			pool.Settings[field] = fingerprints[pool.Hash][field]
+		}
+		pool.Outlier = (len(pool.Nodes) == 1)
+	}
+	return report
+}
+
+// ConsistencyResults returns a warning for each outlier node, compared to the biggest pool.
+func ConsistencyResults(report ConsistencyReport) []ValidationResult {
+	vrs := []ValidationResult{}
+	if len(report.Pools) < 2 {
+		return vrs
+	}
+	ref := report.Pools[0]
+	for _, pool := range report.Pools {
+		if !pool.Outlier || pool.Hash == ref.Hash {
+			continue
+		}
+		var diffs []string
+		for _, field := range report.DifferingFields {
+			if pool.Settings[field] != ref.Settings[field] {
+				diffs = append(diffs, field)
+			}
+		}
+		vrs = append(vrs, ValidationResult{
+			Node:        pool.Nodes[0],
+			Area:        AreaKubelet,
+			Component:   ComponentConfiguration,
+			Setting:     "consistency",
+			Expected:    fmt.Sprintf("same as pool %s (%d nodes)", ref.Hash, len(ref.Nodes)),
+			Detected:    "differs in " + strings.Join(diffs, ","),
+			Severity:    SeverityWarning,
+			RuleID:      RuleKubeletConsistency,
+			Remediation: Remediation(RuleKubeletConsistency),
+		})
+	}
+	return vrs
+}
+
+func (vd *Validator) ValidateKubeletConsistency() ConsistencyReport {
+	report := CheckKubeletConsistency(vd.kubeletConfigs)
+	for nodeName := range vd.kubeletConfigs {
+		vd.checks = append(vd.checks, Check{Node: nodeName, Area: AreaKubelet, RuleID: RuleKubeletConsistency})
+	}
+	vd.results = append(vd.results, ConsistencyResults(report)...)
+	return report
+}
+
+func canonicalValue(obj interface{}) string {
+	data, err := json.Marshal(obj) // maps are marshaled with sorted keys
+	if err != nil {
+		return fmt.Sprintf("%v", obj)
+	}
+	return string(data)
+}
+
+func fingerprintHash(fp map[string]string) string {
+	h := sha256.Sum256([]byte(canonicalValue(fp)))
+	return hex.EncodeToString(h[:])[:poolHashLen]
+}
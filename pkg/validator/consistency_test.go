@@ -0,0 +1,106 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+func TestCheckKubeletConsistency(t *testing.T) {
+	makeConf := func(tmPolicy, reservedCPUs string) *kubeletconfigv1beta1.KubeletConfiguration {
+		return &kubeletconfigv1beta1.KubeletConfiguration{
+			CPUManagerPolicy:      ExpectedCPUManagerPolicy,
+			ReservedSystemCPUs:    reservedCPUs,
+			TopologyManagerPolicy: tmPolicy,
+		}
+	}
+
+	type testCase struct {
+		name             string
+		kubeConfs        map[string]*kubeletconfigv1beta1.KubeletConfiguration
+		expectedPools    int
+		expectedFields   []string
+		expectedOutliers []string
+	}
+
+	testCases := []testCase{
+		{
+			name:          "empty",
+			expectedPools: 0,
+		},
+		{
+			name: "single node",
+			kubeConfs: map[string]*kubeletconfigv1beta1.KubeletConfiguration{
+				"node-0": makeConf(ExpectedTopologyManagerPolicy, "0"),
+			},
+			expectedPools: 1,
+		},
+		{
+			name: "consistent",
+			kubeConfs: map[string]*kubeletconfigv1beta1.KubeletConfiguration{
+				"node-0": makeConf(ExpectedTopologyManagerPolicy, "0"),
+				"node-1": makeConf(ExpectedTopologyManagerPolicy, "0"),
+				"node-2": makeConf(ExpectedTopologyManagerPolicy, "0"),
+			},
+			expectedPools: 1,
+		},
+		{
+			name: "one outlier",
+			kubeConfs: map[string]*kubeletconfigv1beta1.KubeletConfiguration{
+				"node-0": makeConf(ExpectedTopologyManagerPolicy, "0"),
+				"node-1": makeConf(ExpectedTopologyManagerPolicy, "0"),
+				"node-2": makeConf("restricted", "0,1"),
+			},
+			expectedPools:    2,
+			expectedFields:   []string{"reservedSystemCPUs", "topologyManagerPolicy"},
+			expectedOutliers: []string{"node-2"},
+		},
+		{
+			name: "two pools",
+			kubeConfs: map[string]*kubeletconfigv1beta1.KubeletConfiguration{
+				"node-0": makeConf(ExpectedTopologyManagerPolicy, "0"),
+				"node-1": makeConf(ExpectedTopologyManagerPolicy, "0"),
+				"node-2": makeConf(ExpectedTopologyManagerPolicy, "0,1"),
+				"node-3": makeConf(ExpectedTopologyManagerPolicy, "0,1"),
+			},
+			expectedPools:  2,
+			expectedFields: []string{"reservedSystemCPUs"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := CheckKubeletConsistency(tc.kubeConfs)
+			if len(report.Pools) != tc.expectedPools {
+				t.Fatalf("expected %d pools, got %d: %#v", tc.expectedPools, len(report.Pools), report.Pools)
+			}
+			if !reflect.DeepEqual(report.DifferingFields, tc.expectedFields) {
+				t.Errorf("differing fields: expected %v got %v", tc.expectedFields, report.DifferingFields)
+			}
+			if !reflect.DeepEqual(report.Outliers(), tc.expectedOutliers) {
+				t.Errorf("outliers: expected %v got %v", tc.expectedOutliers, report.Outliers())
+			}
+			vrs := ConsistencyResults(report)
+			if len(vrs) != len(tc.expectedOutliers) {
+				t.Errorf("expected %d findings, got %d: %#v", len(tc.expectedOutliers), len(vrs), vrs)
+			}
+		})
+	}
+}
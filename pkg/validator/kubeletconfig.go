@@ -18,6 +18,7 @@ package validator
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -48,13 +49,16 @@ const (
 )
 
 const (
+	// podresources GetAllocatableResources API, needed by the topology updater
 	kubeMinVersionGetAllocatable = "1.23"
 )
 
 func (vd *Validator) ValidateClusterConfig(nodes []corev1.Node) ([]ValidationResult, error) {
 	nodeNames := []string{}
+	nodeVersions := make(map[string]*version.Info)
 	for _, node := range nodes {
 		nodeNames = append(nodeNames, node.Name)
+		nodeVersions[node.Name] = NodeKubeletVersion(node)
 	}
 
 	kc := kubeletconfig.NewKubectlFromEnv(vd.Log)
@@ -67,27 +71,53 @@ func (vd *Validator) ValidateClusterConfig(nodes []corev1.Node) ([]ValidationRes
 		return nil, err
 	}
 
+	vd.kubeletConfigs = kubeConfs
 	vrs := []ValidationResult{}
+	vd.checks = append(vd.checks, Check{Area: AreaCluster, RuleID: RuleClusterWorkerNodes})
 	if len(kubeConfs) == 0 {
 		vrs = append(vrs, ValidationResult{
 			/* no specific nodes: all are missing! */
 			Area: AreaCluster,
 			/* no specific component: there are no nodes at all! */
 			/* no specific Setting: all are missing! */
-			Expected: "worker nodes",
-			Detected: "none",
+			Expected:    "worker nodes",
+			Detected:    "none",
+			Severity:    SeverityError,
+			RuleID:      RuleClusterWorkerNodes,
+			Remediation: Remediation(RuleClusterWorkerNodes),
 		})
 	} else {
 		for nodeName, kubeletConf := range kubeConfs {
-			vrs = append(vrs, vd.ValidateNodeKubeletConfig(nodeName, vd.serverVersion, kubeletConf)...)
+			nodeVersion, ok := nodeVersions[nodeName]
+			if !ok || nodeVersion == nil {
+				// should never happen, but we can still do a best effort check
+				nodeVersion = vd.serverVersion
+			}
+			vrs = append(vrs, vd.ValidateNodeKubeletVersion(nodeName, nodeVersion)...)
+			vrs = append(vrs, vd.ValidateNodeKubeletConfig(nodeName, nodeVersion, kubeletConf)...)
 		}
 	}
 	vd.results = append(vd.results, vrs...)
 	return vrs, nil
 }
 
+func (vd *Validator) ValidateNodeKubeletVersion(nodeName string, nodeVersion *version.Info) []ValidationResult {
+	vd.checks = append(vd.checks,
+		Check{Node: nodeName, Area: AreaKubelet, RuleID: RuleKubeletPodResourcesAllocatable},
+		Check{Node: nodeName, Area: AreaKubelet, RuleID: RuleKubeletVersionSkew},
+	)
+	return ValidateNodeKubeletVersion(nodeName, nodeVersion, vd.serverVersion)
+}
+
 func (vd *Validator) ValidateNodeKubeletConfig(nodeName string, nodeVersion *version.Info, kubeletConf *kubeletconfigv1beta1.KubeletConfiguration) []ValidationResult {
-	vrs := ValidateClusterNodeKubeletConfig(nodeName, nodeVersion, kubeletConf)
+	pol := vd.GetPolicy()
+	vrs := ValidateClusterNodeKubeletConfigWithPolicy(nodeName, nodeVersion, kubeletConf, pol)
+	vd.checks = append(vd.checks, Check{Node: nodeName, Area: AreaKubelet, RuleID: RuleKubeletConfiguration})
+	if kubeletConf != nil {
+		for _, ruleID := range pol.KubeletRules() {
+			vd.checks = append(vd.checks, Check{Node: nodeName, Area: AreaKubelet, RuleID: ruleID})
+		}
+	}
 	result := "OK"
 	if len(vrs) > 0 {
 		result = fmt.Sprintf("%d issues found", len(vrs))
@@ -97,6 +127,10 @@ func (vd *Validator) ValidateNodeKubeletConfig(nodeName string, nodeVersion *ver
 }
 
 func ValidateClusterNodeKubeletConfig(nodeName string, nodeVersion *version.Info, kubeletConf *kubeletconfigv1beta1.KubeletConfiguration) []ValidationResult {
+	return ValidateClusterNodeKubeletConfigWithPolicy(nodeName, nodeVersion, kubeletConf, StrictPolicy())
+}
+
+func ValidateClusterNodeKubeletConfigWithPolicy(nodeName string, nodeVersion *version.Info, kubeletConf *kubeletconfigv1beta1.KubeletConfiguration, pol Policy) []ValidationResult {
 	vrs := []ValidationResult{}
 
 	if kubeletConf == nil {
@@ -105,76 +139,192 @@ func ValidateClusterNodeKubeletConfig(nodeName string, nodeVersion *version.Info
 			Area:      AreaKubelet,
 			Component: ComponentConfiguration,
 			/* no specific Setting: all are missing! */
-			Expected: "any value",
-			Detected: "no configuration",
+			Expected:    "any value",
+			Detected:    "no configuration",
+			Severity:    SeverityError,
+			RuleID:      RuleKubeletConfiguration,
+			Remediation: Remediation(RuleKubeletConfiguration),
 		})
 		return vrs
 	}
 
-	if kubeletConf.CPUManagerPolicy != ExpectedCPUManagerPolicy {
+	if rule := pol.CPUManagerPolicy; rule.IsEnabled() && !rule.Accepts(kubeletConf.CPUManagerPolicy) {
 		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentCPUManager,
-			Setting:   "policy",
-			Expected:  ExpectedCPUManagerPolicy,
-			Detected:  kubeletConf.CPUManagerPolicy,
+			Node:        nodeName,
+			Area:        AreaKubelet,
+			Component:   ComponentCPUManager,
+			Setting:     "policy",
+			Expected:    rule.Expected(),
+			Detected:    kubeletConf.CPUManagerPolicy,
+			Severity:    severityOrDefault(rule.Severity),
+			RuleID:      RuleCPUManagerPolicy,
+			Remediation: Remediation(RuleCPUManagerPolicy),
 		})
 	}
 
-	if kubeletConf.CPUManagerReconcilePeriod.Duration < CPUManagerReconcilePeriodMin ||
-		kubeletConf.CPUManagerReconcilePeriod.Duration > CPUManagerReconcilePeriodMax {
+	if rule := pol.CPUManagerPolicyOptions; rule.IsEnabled() && isKubeletAtLeast(nodeVersion, kubeMinVersionCPUManagerPolicyOptions) {
+		if issues := checkPolicyOptions(nodeVersion, rule, kubeletConf.CPUManagerPolicyOptions, cpuManagerPolicyOptionsMinVersion); len(issues) > 0 {
+			vrs = append(vrs, ValidationResult{
+				Node:        nodeName,
+				Area:        AreaKubelet,
+				Component:   ComponentCPUManager,
+				Setting:     "policy options",
+				Expected:    expectedPolicyOptions(rule),
+				Detected:    formatPolicyOptions(kubeletConf.CPUManagerPolicyOptions) + " (" + strings.Join(issues, "; ") + ")",
+				Severity:    severityOrDefault(rule.Severity),
+				RuleID:      RuleCPUManagerPolicyOptions,
+				Remediation: Remediation(RuleCPUManagerPolicyOptions),
+			})
+		}
+	} else if rule.IsEnabled() && len(rule.Required) > 0 {
+		vrs = append(vrs, tooOldKubeletResult(nodeName, nodeVersion, ComponentCPUManager, kubeMinVersionCPUManagerPolicyOptions, rule.Severity, RuleCPUManagerPolicyOptions))
+	}
+
+	if rule := pol.CPUManagerReconcilePeriod; rule.IsEnabled() && !rule.Accepts(kubeletConf.CPUManagerReconcilePeriod.Duration) {
 		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentCPUManager,
-			Setting:   "reconcile period",
-			Expected:  fmt.Sprintf("in range [%v, %v]", CPUManagerReconcilePeriodMin, CPUManagerReconcilePeriodMax),
-			Detected:  fmt.Sprintf("%v", kubeletConf.CPUManagerReconcilePeriod.Duration),
+			Node:        nodeName,
+			Area:        AreaKubelet,
+			Component:   ComponentCPUManager,
+			Setting:     "reconcile period",
+			Expected:    rule.Expected(),
+			Detected:    fmt.Sprintf("%v", kubeletConf.CPUManagerReconcilePeriod.Duration),
+			Severity:    severityOrDefault(rule.Severity),
+			RuleID:      RuleCPUManagerReconcilePeriod,
+			Remediation: Remediation(RuleCPUManagerReconcilePeriod),
 		})
 	}
-	if kubeletConf.ReservedSystemCPUs == "" {
+
+	if rule := pol.ReservedSystemCPUs; rule.IsEnabled() && kubeletConf.ReservedSystemCPUs == "" {
 		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentConfiguration,
-			Setting:   "CPU",
-			Expected:  "reserved some CPU cores",
-			Detected:  "no reserved CPU cores",
+			Node:        nodeName,
+			Area:        AreaKubelet,
+			Component:   ComponentConfiguration,
+			Setting:     "CPU",
+			Expected:    "reserved some CPU cores",
+			Detected:    "no reserved CPU cores",
+			Severity:    severityOrDefault(rule.Severity),
+			RuleID:      RuleReservedSystemCPUs,
+			Remediation: Remediation(RuleReservedSystemCPUs),
 		})
 	}
 
-	if kubeletConf.MemoryManagerPolicy != ExpectedMemoryManagerPolicy {
+	if rule := pol.MemoryManagerPolicy; rule.IsEnabled() && !rule.Accepts(kubeletConf.MemoryManagerPolicy) {
 		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentMemoryManager,
-			Setting:   "policy",
-			Expected:  ExpectedMemoryManagerPolicy,
-			Detected:  kubeletConf.MemoryManagerPolicy,
+			Node:        nodeName,
+			Area:        AreaKubelet,
+			Component:   ComponentMemoryManager,
+			Setting:     "policy",
+			Expected:    rule.Expected(),
+			Detected:    kubeletConf.MemoryManagerPolicy,
+			Severity:    severityOrDefault(rule.Severity),
+			RuleID:      RuleMemoryManagerPolicy,
+			Remediation: Remediation(RuleMemoryManagerPolicy),
 		})
 	}
 
-	if len(kubeletConf.ReservedMemory) == 0 {
+	if rule := pol.ReservedMemory; rule.IsEnabled() && len(kubeletConf.ReservedMemory) == 0 {
 		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentConfiguration,
-			Setting:   "memory",
-			Expected:  "reserved memory blocks",
-			Detected:  "no reserved memory blocks",
+			Node:        nodeName,
+			Area:        AreaKubelet,
+			Component:   ComponentConfiguration,
+			Setting:     "memory",
+			Expected:    "reserved memory blocks",
+			Detected:    "no reserved memory blocks",
+			Severity:    severityOrDefault(rule.Severity),
+			RuleID:      RuleReservedMemory,
+			Remediation: Remediation(RuleReservedMemory),
 		})
 	}
 
-	if kubeletConf.TopologyManagerPolicy != ExpectedTopologyManagerPolicy {
+	if rule := pol.ReservedMemoryConsistency; rule.IsEnabled() && len(kubeletConf.ReservedMemory) > 0 && isKubeletAtLeast(nodeVersion, kubeMinVersionReservedMemory) {
+		if issues := checkReservedMemoryConsistency(kubeletConf); len(issues) > 0 {
+			vrs = append(vrs, ValidationResult{
+				Node:        nodeName,
+				Area:        AreaKubelet,
+				Component:   ComponentMemoryManager,
+				Setting:     "reserved memory",
+				Expected:    "kubeReserved + systemReserved + evictionHard",
+				Detected:    strings.Join(issues, "; "),
+				Severity:    severityOrDefault(rule.Severity),
+				RuleID:      RuleReservedMemoryConsistency,
+				Remediation: Remediation(RuleReservedMemoryConsistency),
+			})
+		}
+	}
+
+	if rule := pol.TopologyManagerPolicy; rule.IsEnabled() && !rule.Accepts(kubeletConf.TopologyManagerPolicy) {
 		vrs = append(vrs, ValidationResult{
-			Node:      nodeName,
-			Area:      AreaKubelet,
-			Component: ComponentTopologyManager,
-			Setting:   "policy",
-			Expected:  ExpectedTopologyManagerPolicy,
-			Detected:  kubeletConf.TopologyManagerPolicy,
+			Node:        nodeName,
+			Area:        AreaKubelet,
+			Component:   ComponentTopologyManager,
+			Setting:     "policy",
+			Expected:    rule.Expected(),
+			Detected:    kubeletConf.TopologyManagerPolicy,
+			Severity:    severityOrDefault(rule.Severity),
+			RuleID:      RuleTopologyManagerPolicy,
+			Remediation: Remediation(RuleTopologyManagerPolicy),
+		})
+	}
+
+	if rule := pol.TopologyManagerPolicyOptions; rule.IsEnabled() && isKubeletAtLeast(nodeVersion, kubeMinVersionTopologyManagerPolicyOptions) {
+		if issues := checkPolicyOptions(nodeVersion, rule, kubeletConf.TopologyManagerPolicyOptions, topologyManagerPolicyOptionsMinVersion); len(issues) > 0 {
+			vrs = append(vrs, ValidationResult{
+				Node:        nodeName,
+				Area:        AreaKubelet,
+				Component:   ComponentTopologyManager,
+				Setting:     "policy options",
+				Expected:    expectedPolicyOptions(rule),
+				Detected:    formatPolicyOptions(kubeletConf.TopologyManagerPolicyOptions) + " (" + strings.Join(issues, "; ") + ")",
+				Severity:    severityOrDefault(rule.Severity),
+				RuleID:      RuleTopologyManagerPolicyOptions,
+				Remediation: Remediation(RuleTopologyManagerPolicyOptions),
+			})
+		}
+	} else if rule.IsEnabled() && len(rule.Required) > 0 {
+		vrs = append(vrs, tooOldKubeletResult(nodeName, nodeVersion, ComponentTopologyManager, kubeMinVersionTopologyManagerPolicyOptions, rule.Severity, RuleTopologyManagerPolicyOptions))
+	}
+
+	// the kubelet defaults to the container scope if unset
+	if rule := pol.TopologyManagerScope; rule.IsEnabled() && isKubeletAtLeast(nodeVersion, kubeMinVersionTopologyManagerScope) && !rule.Accepts(topologyManagerScope(kubeletConf)) {
+		vrs = append(vrs, ValidationResult{
+			Node:        nodeName,
+			Area:        AreaKubelet,
+			Component:   ComponentTopologyManager,
+			Setting:     "scope",
+			Expected:    rule.Expected(),
+			Detected:    topologyManagerScope(kubeletConf),
+			Severity:    severityOrDefault(rule.Severity),
+			RuleID:      RuleTopologyManagerScope,
+			Remediation: Remediation(RuleTopologyManagerScope),
 		})
 	}
 	return vrs
 }
+
+func topologyManagerScope(kubeletConf *kubeletconfigv1beta1.KubeletConfiguration) string {
+	if kubeletConf.TopologyManagerScope == "" {
+		return kubeletconfigv1beta1.ContainerTopologyManagerScope
+	}
+	return kubeletConf.TopologyManagerScope
+}
+
+func expectedPolicyOptions(rule OptionsRule) string {
+	if len(rule.Required) == 0 {
+		return "supported options"
+	}
+	return formatPolicyOptions(rule.Required)
+}
+
+func tooOldKubeletResult(nodeName string, nodeVersion *version.Info, component, minVersion string, sev Severity, ruleID string) ValidationResult {
+	return ValidationResult{
+		Node:        nodeName,
+		Area:        AreaKubelet,
+		Component:   component,
+		Setting:     "policy options",
+		Expected:    "kubelet " + minVersion + " or newer",
+		Detected:    kubeletVersionString(nodeVersion),
+		Severity:    severityOrDefault(sev),
+		RuleID:      ruleID,
+		Remediation: Remediation(ruleID),
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/go-logr/stdr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/version"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
@@ -257,6 +259,230 @@ func TestKubeletValidations(t *testing.T) {
 	}
 }
 
+func TestKubeletDeeperValidations(t *testing.T) {
+	nodeName := "testNode"
+
+	makeConf := func(mutate func(conf *kubeletconfigv1beta1.KubeletConfiguration)) *kubeletconfigv1beta1.KubeletConfiguration {
+		conf := &kubeletconfigv1beta1.KubeletConfiguration{
+			CPUManagerPolicy: ExpectedCPUManagerPolicy,
+			CPUManagerReconcilePeriod: metav1.Duration{
+				Duration: 5 * time.Second,
+			},
+			MemoryManagerPolicy: ExpectedMemoryManagerPolicy,
+			EvictionHard: map[string]string{
+				"memory.available": "100Mi",
+			},
+			KubeReserved: map[string]string{
+				"memory": "256Mi",
+			},
+			SystemReserved: map[string]string{
+				"memory": "256Mi",
+			},
+			ReservedMemory: []kubeletconfigv1beta1.MemoryReservation{
+				{
+					NumaNode: 0,
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("306Mi"),
+					},
+				},
+				{
+					NumaNode: 1,
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("306Mi"),
+					},
+				},
+			},
+			ReservedSystemCPUs:    "0,1",
+			TopologyManagerPolicy: ExpectedTopologyManagerPolicy,
+		}
+		if mutate != nil {
+			mutate(conf)
+		}
+		return conf
+	}
+
+	type testCase struct {
+		name        string
+		policy      Policy
+		kubeletConf *kubeletconfigv1beta1.KubeletConfiguration
+		nodeVersion *version.Info
+		expected    []ValidationResult
+	}
+
+	testCases := []testCase{
+		{
+			name:        "correct",
+			policy:      StrictPolicy(),
+			kubeletConf: makeConf(nil),
+			expected:    []ValidationResult{},
+		},
+		{
+			name:   "inconsistent reserved memory",
+			policy: StrictPolicy(),
+			kubeletConf: makeConf(func(conf *kubeletconfigv1beta1.KubeletConfiguration) {
+				conf.KubeReserved["memory"] = "512Mi"
+			}),
+			expected: []ValidationResult{
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentMemoryManager,
+					Setting:   "reserved memory",
+				},
+			},
+		},
+		{
+			name:   "inconsistent reserved hugepages",
+			policy: StrictPolicy(),
+			kubeletConf: makeConf(func(conf *kubeletconfigv1beta1.KubeletConfiguration) {
+				conf.SystemReserved["hugepages-2Mi"] = "4Mi"
+				conf.ReservedMemory[0].Limits[corev1.ResourceName("hugepages-2Mi")] = resource.MustParse("2Mi")
+			}),
+			expected: []ValidationResult{
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentMemoryManager,
+					Setting:   "reserved memory",
+				},
+			},
+		},
+		{
+			name:   "relative eviction threshold",
+			policy: StrictPolicy(),
+			kubeletConf: makeConf(func(conf *kubeletconfigv1beta1.KubeletConfiguration) {
+				conf.EvictionHard["memory.available"] = "5%"
+			}),
+			expected: []ValidationResult{},
+		},
+		{
+			name:   "relative eviction threshold with inconsistent reserved hugepages",
+			policy: StrictPolicy(),
+			kubeletConf: makeConf(func(conf *kubeletconfigv1beta1.KubeletConfiguration) {
+				conf.EvictionHard["memory.available"] = "5%"
+				conf.SystemReserved["hugepages-2Mi"] = "4Mi"
+				conf.ReservedMemory[0].Limits[corev1.ResourceName("hugepages-2Mi")] = resource.MustParse("2Mi")
+			}),
+			expected: []ValidationResult{
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentMemoryManager,
+					Setting:   "reserved memory",
+				},
+			},
+		},
+		{
+			name:   "unknown cpu manager policy option",
+			policy: StrictPolicy(),
+			kubeletConf: makeConf(func(conf *kubeletconfigv1beta1.KubeletConfiguration) {
+				conf.CPUManagerPolicyOptions = map[string]string{
+					"full-pcpus-only": "true",
+					"foobar":          "true",
+				}
+			}),
+			expected: []ValidationResult{
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentCPUManager,
+					Setting:   "policy options",
+				},
+			},
+		},
+		{
+			name:   "cpu manager policy option too new for the kubelet",
+			policy: StrictPolicy(),
+			kubeletConf: makeConf(func(conf *kubeletconfigv1beta1.KubeletConfiguration) {
+				conf.CPUManagerPolicyOptions = map[string]string{
+					"distribute-cpus-across-cores": "true",
+				}
+			}),
+			nodeVersion: &version.Info{
+				Major:      "1",
+				Minor:      "28",
+				GitVersion: "v1.28.4",
+			},
+			expected: []ValidationResult{
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentCPUManager,
+					Setting:   "policy options",
+				},
+			},
+		},
+		{
+			name: "missing required topology manager policy option",
+			policy: func() Policy {
+				pol := StrictPolicy()
+				pol.TopologyManagerPolicyOptions.Required = map[string]string{
+					"prefer-closest-numa-nodes": "true",
+				}
+				return pol
+			}(),
+			kubeletConf: makeConf(nil),
+			expected: []ValidationResult{
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentTopologyManager,
+					Setting:   "policy options",
+				},
+			},
+		},
+		{
+			name: "required topology manager policy option on old kubelet",
+			policy: func() Policy {
+				pol := StrictPolicy()
+				pol.TopologyManagerPolicyOptions.Required = map[string]string{
+					"prefer-closest-numa-nodes": "true",
+				}
+				return pol
+			}(),
+			kubeletConf: makeConf(nil),
+			nodeVersion: &version.Info{
+				Major:      "1",
+				Minor:      "24",
+				GitVersion: "v1.24.2",
+			},
+			expected: []ValidationResult{
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentTopologyManager,
+					Setting:   "policy options",
+				},
+			},
+		},
+		{
+			name: "required topology manager policy option",
+			policy: func() Policy {
+				pol := StrictPolicy()
+				pol.TopologyManagerPolicyOptions.Required = map[string]string{
+					"prefer-closest-numa-nodes": "true",
+				}
+				return pol
+			}(),
+			kubeletConf: makeConf(func(conf *kubeletconfigv1beta1.KubeletConfiguration) {
+				conf.TopologyManagerPolicyOptions = map[string]string{
+					"prefer-closest-numa-nodes": "true",
+				}
+			}),
+			expected: []ValidationResult{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ValidateClusterNodeKubeletConfigWithPolicy(nodeName, tc.nodeVersion, tc.kubeletConf, tc.policy)
+			if !matchValidationResults(tc.expected, got) {
+				t.Fatalf("validation failed:\nexpected=%#v\ngot=%#v", tc.expected, got)
+			}
+		})
+	}
+}
+
 func matchValidationResults(expected, got []ValidationResult) bool {
 	if len(expected) != len(got) {
 		return false
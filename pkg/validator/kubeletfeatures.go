@@ -0,0 +1,207 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/version"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+// minimum kubelet versions supporting the configuration settings we check
+const (
+	kubeMinVersionTopologyManagerScope         = "1.20"
+	kubeMinVersionReservedMemory               = "1.21"
+	kubeMinVersionCPUManagerPolicyOptions      = "1.22"
+	kubeMinVersionTopologyManagerPolicyOptions = "1.26"
+)
+
+// the kubelet refuses to start if it finds unknown policy options, so we track
+// the minimum kubelet version supporting each of them.
+var cpuManagerPolicyOptionsMinVersion = map[string]string{
+	"full-pcpus-only":                  "1.22",
+	"distribute-cpus-across-numa":      "1.23",
+	"align-by-socket":                  "1.25",
+	"distribute-cpus-across-cores":     "1.31",
+	"strict-cpu-reservation":           "1.32",
+	"prefer-align-cpus-by-uncorecache": "1.32",
+}
+
+var topologyManagerPolicyOptionsMinVersion = map[string]string{
+	"prefer-closest-numa-nodes": "1.26",
+	"max-allowable-numa-nodes":  "1.31",
+}
+
+const (
+	evictionSignalMemoryAvailable = "memory.available"
+)
+
+// isKubeletAtLeast returns true if the kubelet version is unknown or at least refver.
+func isKubeletAtLeast(nodeVersion *version.Info, refver string) bool {
+	if nodeVersion == nil || nodeVersion.GitVersion == "" {
+		return true
+	}
+	ok, err := isAPIVersionAtLeast(nodeVersion.GitVersion, refver)
+	if err != nil {
+		return true // can't tell, so let's not filter out the check
+	}
+	return ok
+}
+
+func kubeletVersionString(nodeVersion *version.Info) string {
+	if nodeVersion == nil {
+		return "unknown"
+	}
+	return nodeVersion.GitVersion
+}
+
+// checkPolicyOptions returns a list of human readable problems with the given policy options, empty if none.
+func checkPolicyOptions(nodeVersion *version.Info, rule OptionsRule, options map[string]string, known map[string]string) []string {
+	var issues []string
+	for _, name := range sortedKeys(rule.Required) {
+		value, ok := options[name]
+		if !ok {
+			issues = append(issues, fmt.Sprintf("%s: missing", name))
+			continue
+		}
+		if expected := rule.Required[name]; expected != "" && !strings.EqualFold(value, expected) {
+			issues = append(issues, fmt.Sprintf("%s: expected %q got %q", name, expected, value))
+		}
+	}
+	if !rule.CheckSupported {
+		return issues
+	}
+	for _, name := range sortedKeys(options) {
+		minVer, ok := known[name]
+		if !ok {
+			issues = append(issues, fmt.Sprintf("%s: unknown option", name))
+			continue
+		}
+		if !isKubeletAtLeast(nodeVersion, minVer) {
+			issues = append(issues, fmt.Sprintf("%s: requires kubelet %s or newer", name, minVer))
+		}
+	}
+	return issues
+}
+
+func formatPolicyOptions(options map[string]string) string {
+	if len(options) == 0 {
+		return "none"
+	}
+	items := []string{}
+	for _, name := range sortedKeys(options) {
+		items = append(items, name+"="+options[name])
+	}
+	return strings.Join(items, ",")
+}
+
+func sortedKeys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkReservedMemoryConsistency verifies the sum of the per-NUMA reserved memory matches the
+// node-level reservations, like the kubelet does on startup when the static memory manager is enabled.
+// Returns a list of human readable problems, empty if none.
+func checkReservedMemoryConsistency(kubeletConf *kubeletconfigv1beta1.KubeletConfiguration) []string {
+	numaReserved := make(map[corev1.ResourceName]*resource.Quantity)
+	for _, reservation := range kubeletConf.ReservedMemory {
+		for resName, qty := range reservation.Limits {
+			if _, ok := numaReserved[resName]; !ok {
+				numaReserved[resName] = resource.NewQuantity(0, resource.BinarySI)
+			}
+			numaReserved[resName].Add(qty)
+		}
+	}
+
+	nodeReserved := make(map[corev1.ResourceName]*resource.Quantity)
+	var issues []string
+	addReserved := func(source string, data map[string]string) {
+		for name, value := range data {
+			resName := corev1.ResourceName(name)
+			if resName != corev1.ResourceMemory && !isHugePageResourceName(resName) {
+				continue
+			}
+			qty, err := resource.ParseQuantity(value)
+			if err != nil {
+				issues = append(issues, fmt.Sprintf("%s %s: invalid quantity %q", source, name, value))
+				continue
+			}
+			if _, ok := nodeReserved[resName]; !ok {
+				nodeReserved[resName] = resource.NewQuantity(0, resource.BinarySI)
+			}
+			nodeReserved[resName].Add(qty)
+		}
+	}
+	addReserved("kubeReserved", kubeletConf.KubeReserved)
+	addReserved("systemReserved", kubeletConf.SystemReserved)
+	skipMemory := false
+	if value, ok := kubeletConf.EvictionHard[evictionSignalMemoryAvailable]; ok {
+		if strings.HasSuffix(value, "%") {
+			// relative to the node capacity, which we don't know here. The hugepages don't depend on it.
+			skipMemory = true
+		} else {
+			addReserved("evictionHard", map[string]string{string(corev1.ResourceMemory): value})
+		}
+	}
+
+	resNames := make(map[corev1.ResourceName]struct{})
+	for resName := range numaReserved {
+		resNames[resName] = struct{}{}
+	}
+	for resName := range nodeReserved {
+		resNames[resName] = struct{}{}
+	}
+	if skipMemory {
+		delete(resNames, corev1.ResourceMemory)
+	}
+	names := make([]string, 0, len(resNames))
+	for resName := range resNames {
+		names = append(names, string(resName))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		resName := corev1.ResourceName(name)
+		got := quantityOrZero(numaReserved[resName])
+		expected := quantityOrZero(nodeReserved[resName])
+		if got.Cmp(expected) != 0 {
+			issues = append(issues, fmt.Sprintf("%s: reserved per NUMA %s, expected %s", name, got.String(), expected.String()))
+		}
+	}
+	return issues
+}
+
+func quantityOrZero(qty *resource.Quantity) resource.Quantity {
+	if qty == nil {
+		return *resource.NewQuantity(0, resource.BinarySI)
+	}
+	return *qty
+}
+
+func isHugePageResourceName(name corev1.ResourceName) bool {
+	return strings.HasPrefix(string(name), corev1.ResourceHugePagesPrefix)
+}
@@ -0,0 +1,114 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+const (
+	ComponentKubeletVersion = "kubelet version"
+	ComponentPodResources   = "podresources API"
+)
+
+const (
+	// see https://kubernetes.io/releases/version-skew-policy/#kubelet
+	kubeletMaxMinorSkew = 3
+)
+
+// NodeKubeletVersion returns the kubelet version reported by the node, or nil if not reported
+func NodeKubeletVersion(node corev1.Node) *version.Info {
+	kubeletVersion := node.Status.NodeInfo.KubeletVersion
+	if kubeletVersion == "" {
+		return nil
+	}
+	ret := &version.Info{
+		GitVersion: kubeletVersion,
+	}
+	if ver, err := utilversion.ParseGeneric(kubeletVersion); err == nil {
+		ret.Major = fmt.Sprintf("%d", ver.Major())
+		ret.Minor = fmt.Sprintf("%d", ver.Minor())
+	}
+	return ret
+}
+
+// ValidateNodeKubeletVersion checks the version-dependent requirements of the kubelet running on the node,
+// and its skew compared to the control plane version. Unknown versions are not checked.
+func ValidateNodeKubeletVersion(nodeName string, kubeletVersion, serverVersion *version.Info) []ValidationResult {
+	vrs := []ValidationResult{}
+	if kubeletVersion == nil || kubeletVersion.GitVersion == "" {
+		return vrs
+	}
+
+	if !isKubeletAtLeast(kubeletVersion, kubeMinVersionGetAllocatable) {
+		vrs = append(vrs, ValidationResult{
+			Node:        nodeName,
+			Area:        AreaKubelet,
+			Component:   ComponentPodResources,
+			Setting:     "GetAllocatableResources",
+			Expected:    "kubelet " + kubeMinVersionGetAllocatable + " or newer",
+			Detected:    kubeletVersion.GitVersion,
+			Severity:    SeverityError,
+			RuleID:      RuleKubeletPodResourcesAllocatable,
+			Remediation: Remediation(RuleKubeletPodResourcesAllocatable),
+		})
+	}
+
+	if serverVersion == nil || serverVersion.GitVersion == "" {
+		return vrs
+	}
+	if vr, ok := checkKubeletVersionSkew(nodeName, kubeletVersion.GitVersion, serverVersion.GitVersion); ok {
+		vrs = append(vrs, vr)
+	}
+	return vrs
+}
+
+func checkKubeletVersionSkew(nodeName, kubeletVersion, serverVersion string) (ValidationResult, bool) {
+	kubeletVer, err := utilversion.ParseGeneric(kubeletVersion)
+	if err != nil {
+		return ValidationResult{}, false
+	}
+	serverVer, err := utilversion.ParseGeneric(serverVersion)
+	if err != nil {
+		return ValidationResult{}, false
+	}
+	if kubeletVer.Major() == serverVer.Major() && kubeletVer.Minor() == serverVer.Minor() {
+		return ValidationResult{}, false
+	}
+
+	vr := ValidationResult{
+		Node:        nodeName,
+		Area:        AreaKubelet,
+		Component:   ComponentKubeletVersion,
+		Setting:     "skew",
+		Expected:    fmt.Sprintf("same as control plane %s", serverVersion),
+		Detected:    kubeletVersion,
+		Severity:    SeverityInfo, // expected during upgrades
+		RuleID:      RuleKubeletVersionSkew,
+		Remediation: Remediation(RuleKubeletVersionSkew),
+	}
+	skew := int(serverVer.Minor()) - int(kubeletVer.Minor())
+	if kubeletVer.Major() != serverVer.Major() || skew < 0 || skew > kubeletMaxMinorSkew {
+		vr.Expected = fmt.Sprintf("at most %d minor versions older than control plane %s, never newer", kubeletMaxMinorSkew, serverVersion)
+		vr.Severity = SeverityError
+	}
+	return vr, true
+}
@@ -0,0 +1,132 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+func TestNodeKubeletVersion(t *testing.T) {
+	node := corev1.Node{}
+	if ver := NodeKubeletVersion(node); ver != nil {
+		t.Fatalf("unexpected version for node without kubelet version: %#v", ver)
+	}
+
+	node.Status.NodeInfo.KubeletVersion = "v1.29.4+k3s1"
+	ver := NodeKubeletVersion(node)
+	if ver == nil {
+		t.Fatalf("missing version for node with kubelet version")
+	}
+	if ver.Major != "1" || ver.Minor != "29" || ver.GitVersion != "v1.29.4+k3s1" {
+		t.Fatalf("unexpected version: %#v", ver)
+	}
+}
+
+func TestValidateNodeKubeletVersion(t *testing.T) {
+	nodeName := "testNode"
+
+	type testCase struct {
+		name             string
+		kubeletVersion   *version.Info
+		serverVersion    *version.Info
+		expected         []ValidationResult
+		expectedSeverity Severity
+	}
+
+	testCases := []testCase{
+		{
+			name:          "unknown kubelet version",
+			serverVersion: &version.Info{GitVersion: "v1.29.1"},
+			expected:      []ValidationResult{},
+		},
+		{
+			name:           "same version",
+			kubeletVersion: &version.Info{GitVersion: "v1.29.1"},
+			serverVersion:  &version.Info{GitVersion: "v1.29.4"},
+			expected:       []ValidationResult{},
+		},
+		{
+			name:           "unknown server version",
+			kubeletVersion: &version.Info{GitVersion: "v1.29.1"},
+			expected:       []ValidationResult{},
+		},
+		{
+			name:           "kubelet older, within skew",
+			kubeletVersion: &version.Info{GitVersion: "v1.28.1"},
+			serverVersion:  &version.Info{GitVersion: "v1.29.4"},
+			expected: []ValidationResult{
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentKubeletVersion,
+					Setting:   "skew",
+				},
+			},
+			expectedSeverity: SeverityInfo,
+		},
+		{
+			name:           "kubelet newer than control plane",
+			kubeletVersion: &version.Info{GitVersion: "v1.30.0"},
+			serverVersion:  &version.Info{GitVersion: "v1.29.4"},
+			expected: []ValidationResult{
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentKubeletVersion,
+					Setting:   "skew",
+				},
+			},
+			expectedSeverity: SeverityError,
+		},
+		{
+			name:           "kubelet too old for GetAllocatable and skewed",
+			kubeletVersion: &version.Info{GitVersion: "v1.22.3"},
+			serverVersion:  &version.Info{GitVersion: "v1.26.0"},
+			expected: []ValidationResult{
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentPodResources,
+				},
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentKubeletVersion,
+					Setting:   "skew",
+				},
+			},
+			expectedSeverity: SeverityError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ValidateNodeKubeletVersion(nodeName, tc.kubeletVersion, tc.serverVersion)
+			if !matchValidationResults(tc.expected, got) {
+				t.Fatalf("validation failed:\nexpected=%#v\ngot=%#v", tc.expected, got)
+			}
+			for _, vr := range got {
+				if vr.Severity != tc.expectedSeverity {
+					t.Errorf("unexpected severity for %s: got %q expected %q", vr.String(), vr.Severity, tc.expectedSeverity)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,334 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	machineconfigv1 "github.com/openshift/api/machineconfiguration/v1"
+)
+
+const (
+	AreaMachineConfig = "machineconfig"
+)
+
+const (
+	ComponentMachineConfigPool = "MachineConfigPool"
+)
+
+const (
+	KindKubeletConfig      = "KubeletConfig"
+	KindPerformanceProfile = "PerformanceProfile"
+	KindMachineConfigPool  = "MachineConfigPool"
+)
+
+const (
+	// set by the machine config daemon on each node
+	annotationCurrentConfig = "machineconfiguration.openshift.io/currentConfig"
+	annotationDesiredConfig = "machineconfiguration.openshift.io/desiredConfig"
+
+	// pools which nodes belong to unless a custom pool selects them
+	poolWorker = "worker"
+	poolMaster = "master"
+)
+
+// OpenShiftTarget describes how the kubelet configuration of a node is managed on OpenShift.
+type OpenShiftTarget struct {
+	Pool string
+	// Kind and Name identify the object to change to fix the kubelet configuration of the node
+	Kind string
+	Name string
+	// Updating is true if the node or its pool are rolling out a new configuration
+	Updating bool
+	// Desired is the kubelet configuration requested for the pool, nil if none.
+	Desired *kubeletconfigv1beta1.KubeletConfiguration
+	// DesiredFields are the kubelet configuration fields explicitly set in the desired configuration
+	DesiredFields []string
+}
+
+// Ref returns the object to change in the "Kind/name" form.
+func (ot OpenShiftTarget) Ref() string {
+	if ot.Kind == "" {
+		return ""
+	}
+	return ot.Kind + "/" + ot.Name
+}
+
+// ResolveOpenShiftTargets maps each node to its MachineConfigPool and to the KubeletConfig, or the PerformanceProfile
+// which owns it, configuring the pool. Nodes not belonging to any pool are omitted.
+func ResolveOpenShiftTargets(nodes []corev1.Node, mcps []machineconfigv1.MachineConfigPool, kcs []machineconfigv1.KubeletConfig) (map[string]OpenShiftTarget, error) {
+	ret := make(map[string]OpenShiftTarget)
+	for _, node := range nodes {
+		mcp, err := findPoolForNode(node, mcps)
+		if err != nil {
+			return nil, err
+		}
+		if mcp == nil {
+			continue
+		}
+		target := OpenShiftTarget{
+			Pool:     mcp.Name,
+			Kind:     KindMachineConfigPool,
+			Name:     mcp.Name,
+			Updating: isPoolUpdating(*mcp) || isNodeUpdating(node),
+		}
+		kc, err := findKubeletConfigForPool(*mcp, kcs)
+		if err != nil {
+			return nil, err
+		}
+		if kc != nil {
+			target.Kind, target.Name = kubeletConfigOwner(*kc)
+			target.Desired, target.DesiredFields, err = decodeKubeletConfigSpec(*kc)
+			if err != nil {
+				return nil, err
+			}
+		}
+		ret[node.Name] = target
+	}
+	return ret, nil
+}
+
+// ValidateOpenShiftConfig checks the kubelet configuration on OpenShift against the KubeletConfig objects
+// and attributes the kubelet findings to the object to change. Must be called after ValidateClusterConfig.
+func (vd *Validator) ValidateOpenShiftConfig(nodes []corev1.Node, mcps []machineconfigv1.MachineConfigPool, kcs []machineconfigv1.KubeletConfig) ([]ValidationResult, error) {
+	targets, err := ResolveOpenShiftTargets(nodes, mcps, kcs)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range vd.results {
+		vr := &vd.results[idx]
+		target, ok := targets[vr.Node]
+		if !ok || vr.Area != AreaKubelet {
+			continue
+		}
+		vr.Target = target.Ref()
+		vr.Remediation = openShiftRemediation(vr.Remediation, target)
+	}
+
+	vrs := []ValidationResult{}
+	for _, node := range nodes {
+		vd.checks = append(vd.checks, Check{Node: node.Name, Area: AreaMachineConfig, RuleID: RuleOpenShiftMachineConfigPool})
+		target, ok := targets[node.Name]
+		if !ok {
+			vrs = append(vrs, ValidationResult{
+				Node:        node.Name,
+				Area:        AreaMachineConfig,
+				Component:   ComponentMachineConfigPool,
+				Setting:     "pool",
+				Expected:    "node selected by a pool",
+				Detected:    "no pool",
+				Severity:    SeverityWarning,
+				RuleID:      RuleOpenShiftMachineConfigPool,
+				Remediation: Remediation(RuleOpenShiftMachineConfigPool),
+			})
+			continue
+		}
+
+		vd.checks = append(vd.checks,
+			Check{Node: node.Name, Area: AreaMachineConfig, RuleID: RuleOpenShiftPoolRollout},
+			Check{Node: node.Name, Area: AreaMachineConfig, RuleID: RuleOpenShiftKubeletDrift},
+		)
+		if target.Updating {
+			vrs = append(vrs, ValidationResult{
+				Node:        node.Name,
+				Area:        AreaMachineConfig,
+				Component:   ComponentMachineConfigPool,
+				Setting:     "rollout",
+				Expected:    "updated",
+				Detected:    fmt.Sprintf("pool %q updating", target.Pool),
+				Severity:    SeverityWarning,
+				RuleID:      RuleOpenShiftPoolRollout,
+				Remediation: Remediation(RuleOpenShiftPoolRollout),
+				Target:      KindMachineConfigPool + "/" + target.Pool,
+			})
+		}
+		vrs = append(vrs, kubeletConfigDrift(node.Name, target, vd.kubeletConfigs[node.Name])...)
+	}
+
+	vd.results = append(vd.results, vrs...)
+	return vrs, nil
+}
+
+// kubeletConfigDrift reports the desired settings which are not live yet. This is expected while a rollout
+// is in progress, so in that case the findings are informational.
+func kubeletConfigDrift(nodeName string, target OpenShiftTarget, live *kubeletconfigv1beta1.KubeletConfiguration) []ValidationResult {
+	vrs := []ValidationResult{}
+	if target.Desired == nil || live == nil {
+		return vrs
+	}
+	severity := SeverityError
+	if target.Updating {
+		severity = SeverityInfo
+	}
+	desiredFP := KubeletFingerprint(target.Desired)
+	liveFP := KubeletFingerprint(live)
+	for _, field := range target.DesiredFields {
+		desired, ok := desiredFP[field]
+		if !ok || desired == liveFP[field] {
+			continue
+		}
+		vrs = append(vrs, ValidationResult{
+			Node:        nodeName,
+			Area:        AreaMachineConfig,
+			Component:   ComponentConfiguration,
+			Setting:     field,
+			Expected:    desired,
+			Detected:    liveFP[field],
+			Severity:    severity,
+			RuleID:      RuleOpenShiftKubeletDrift,
+			Remediation: Remediation(RuleOpenShiftKubeletDrift),
+			Target:      target.Ref(),
+		})
+	}
+	return vrs
+}
+
+func openShiftRemediation(remediation string, target OpenShiftTarget) string {
+	var hint string
+	switch target.Kind {
+	case KindPerformanceProfile:
+		hint = fmt.Sprintf("edit the PerformanceProfile %q, the kubelet configuration is generated from it", target.Name)
+	case KindKubeletConfig:
+		hint = fmt.Sprintf("edit spec.kubeletConfig of the KubeletConfig %q", target.Name)
+	default:
+		hint = fmt.Sprintf("create a KubeletConfig or a PerformanceProfile selecting the MachineConfigPool %q", target.Pool)
+	}
+	if remediation == "" {
+		return hint
+	}
+	return remediation + "; on OpenShift, " + hint
+}
+
+// findPoolForNode mimics the machine config operator: the master pool wins, then custom pools, then the worker pool.
+func findPoolForNode(node corev1.Node, mcps []machineconfigv1.MachineConfigPool) (*machineconfigv1.MachineConfigPool, error) {
+	var candidates []*machineconfigv1.MachineConfigPool
+	for idx := range mcps {
+		mcp := &mcps[idx]
+		if mcp.Spec.NodeSelector == nil {
+			continue
+		}
+		sel, err := metav1.LabelSelectorAsSelector(mcp.Spec.NodeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("pool %q: invalid node selector: %w", mcp.Name, err)
+		}
+		if sel.Empty() || !sel.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		candidates = append(candidates, mcp)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		pi, pj := poolPriority(candidates[i].Name), poolPriority(candidates[j].Name)
+		if pi != pj {
+			return pi < pj
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates[0], nil
+}
+
+func poolPriority(name string) int {
+	switch name {
+	case poolMaster:
+		return 0
+	case poolWorker:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// findKubeletConfigForPool returns the KubeletConfig selecting the pool. If many do, the machine config operator
+// uses the most recent one.
+func findKubeletConfigForPool(mcp machineconfigv1.MachineConfigPool, kcs []machineconfigv1.KubeletConfig) (*machineconfigv1.KubeletConfig, error) {
+	var ret *machineconfigv1.KubeletConfig
+	for idx := range kcs {
+		kc := &kcs[idx]
+		if kc.Spec.MachineConfigPoolSelector == nil {
+			continue
+		}
+		sel, err := metav1.LabelSelectorAsSelector(kc.Spec.MachineConfigPoolSelector)
+		if err != nil {
+			return nil, fmt.Errorf("kubeletconfig %q: invalid pool selector: %w", kc.Name, err)
+		}
+		if !sel.Matches(labels.Set(mcp.Labels)) {
+			continue
+		}
+		if ret == nil || ret.CreationTimestamp.Before(&kc.CreationTimestamp) ||
+			(ret.CreationTimestamp.Equal(&kc.CreationTimestamp) && kc.Name > ret.Name) {
+			ret = kc
+		}
+	}
+	return ret, nil
+}
+
+func kubeletConfigOwner(kc machineconfigv1.KubeletConfig) (string, string) {
+	for _, ref := range kc.OwnerReferences {
+		if ref.Kind == KindPerformanceProfile {
+			return KindPerformanceProfile, ref.Name
+		}
+	}
+	return KindKubeletConfig, kc.Name
+}
+
+func decodeKubeletConfigSpec(kc machineconfigv1.KubeletConfig) (*kubeletconfigv1beta1.KubeletConfiguration, []string, error) {
+	if kc.Spec.KubeletConfig == nil || len(kc.Spec.KubeletConfig.Raw) == 0 {
+		return nil, nil, nil
+	}
+	raw := kc.Spec.KubeletConfig.Raw
+	conf := &kubeletconfigv1beta1.KubeletConfiguration{}
+	if err := json.Unmarshal(raw, conf); err != nil {
+		return nil, nil, fmt.Errorf("kubeletconfig %q: cannot decode the kubelet configuration: %w", kc.Name, err)
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, nil, fmt.Errorf("kubeletconfig %q: cannot decode the kubelet configuration: %w", kc.Name, err)
+	}
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return conf, names, nil
+}
+
+func isPoolUpdating(mcp machineconfigv1.MachineConfigPool) bool {
+	for _, cond := range mcp.Status.Conditions {
+		if cond.Type == machineconfigv1.MachineConfigPoolUpdating && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	if mcp.Spec.Configuration.Name != "" && mcp.Spec.Configuration.Name != mcp.Status.Configuration.Name {
+		return true
+	}
+	return mcp.Status.UpdatedMachineCount < mcp.Status.MachineCount
+}
+
+func isNodeUpdating(node corev1.Node) bool {
+	current, desired := node.Annotations[annotationCurrentConfig], node.Annotations[annotationDesiredConfig]
+	return desired != "" && current != desired
+}
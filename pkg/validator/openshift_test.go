@@ -0,0 +1,224 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	machineconfigv1 "github.com/openshift/api/machineconfiguration/v1"
+)
+
+func TestResolveOpenShiftTargets(t *testing.T) {
+	nodes := []corev1.Node{
+		makeOpenShiftNode("worker-0", map[string]string{"node-role.kubernetes.io/worker": ""}),
+		makeOpenShiftNode("worker-cnf-0", map[string]string{"node-role.kubernetes.io/worker": "", "node-role.kubernetes.io/worker-cnf": ""}),
+		makeOpenShiftNode("infra-0", map[string]string{"node-role.kubernetes.io/infra": ""}),
+	}
+	mcps := []machineconfigv1.MachineConfigPool{
+		makePool("worker", "node-role.kubernetes.io/worker", false),
+		makePool("worker-cnf", "node-role.kubernetes.io/worker-cnf", true),
+	}
+	kcs := []machineconfigv1.KubeletConfig{
+		makeKubeletConfig("tas-worker", "worker", `{"cpuManagerPolicy":"static"}`, ""),
+		makeKubeletConfig("performance-cnf", "worker-cnf", `{"cpuManagerPolicy":"static","topologyManagerPolicy":"single-numa-node"}`, "cnf"),
+	}
+
+	targets, err := ResolveOpenShiftTargets(nodes, mcps, kcs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	type testCase struct {
+		node     string
+		expected string
+		updating bool
+		fields   int
+	}
+	for _, tc := range []testCase{
+		{node: "worker-0", expected: "KubeletConfig/tas-worker", fields: 1},
+		{node: "worker-cnf-0", expected: "PerformanceProfile/cnf", updating: true, fields: 2},
+	} {
+		t.Run(tc.node, func(t *testing.T) {
+			target, ok := targets[tc.node]
+			if !ok {
+				t.Fatalf("missing target for node %q", tc.node)
+			}
+			if target.Ref() != tc.expected {
+				t.Errorf("target mismatch: got %q expected %q", target.Ref(), tc.expected)
+			}
+			if target.Updating != tc.updating {
+				t.Errorf("updating mismatch: got %v expected %v", target.Updating, tc.updating)
+			}
+			if len(target.DesiredFields) != tc.fields {
+				t.Errorf("desired fields mismatch: got %v", target.DesiredFields)
+			}
+		})
+	}
+	if _, ok := targets["infra-0"]; ok {
+		t.Errorf("unexpected target for node without pool")
+	}
+}
+
+func TestValidateOpenShiftConfig(t *testing.T) {
+	nodes := []corev1.Node{
+		makeOpenShiftNode("worker-0", map[string]string{"node-role.kubernetes.io/worker": ""}),
+		makeOpenShiftNode("worker-cnf-0", map[string]string{"node-role.kubernetes.io/worker-cnf": ""}),
+		makeOpenShiftNode("infra-0", map[string]string{"node-role.kubernetes.io/infra": ""}),
+	}
+	mcps := []machineconfigv1.MachineConfigPool{
+		makePool("worker", "node-role.kubernetes.io/worker", false),
+		makePool("worker-cnf", "node-role.kubernetes.io/worker-cnf", true),
+	}
+	kcs := []machineconfigv1.KubeletConfig{
+		makeKubeletConfig("tas-worker", "worker", `{"cpuManagerPolicy":"static"}`, ""),
+		makeKubeletConfig("performance-cnf", "worker-cnf", `{"topologyManagerPolicy":"single-numa-node"}`, "cnf"),
+	}
+
+	vd := Validator{
+		results: []ValidationResult{
+			{
+				Node:        "worker-0",
+				Area:        AreaKubelet,
+				Component:   ComponentCPUManager,
+				Setting:     "policy",
+				Remediation: Remediation(RuleCPUManagerPolicy),
+			},
+		},
+		kubeletConfigs: map[string]*kubeletconfigv1beta1.KubeletConfiguration{
+			"worker-0":     {CPUManagerPolicy: "none"},
+			"worker-cnf-0": {TopologyManagerPolicy: "none"},
+		},
+	}
+	got, err := vd.ValidateOpenShiftConfig(nodes, mcps, kcs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []ValidationResult{
+		{
+			Node:      "worker-0",
+			Area:      AreaMachineConfig,
+			Component: ComponentConfiguration,
+			Setting:   "cpuManagerPolicy",
+		},
+		{
+			Node:      "worker-cnf-0",
+			Area:      AreaMachineConfig,
+			Component: ComponentMachineConfigPool,
+			Setting:   "rollout",
+		},
+		{
+			Node:      "worker-cnf-0",
+			Area:      AreaMachineConfig,
+			Component: ComponentConfiguration,
+			Setting:   "topologyManagerPolicy",
+		},
+		{
+			Node:      "infra-0",
+			Area:      AreaMachineConfig,
+			Component: ComponentMachineConfigPool,
+			Setting:   "pool",
+		},
+	}
+	if !matchValidationResults(expected, got) {
+		t.Fatalf("validation failed:\nexpected=%#v\ngot=%#v", expected, got)
+	}
+
+	for _, vr := range got {
+		if vr.Setting == "cpuManagerPolicy" && vr.GetSeverity() != SeverityError {
+			t.Errorf("drift on an updated pool should be an error, got %v", vr.GetSeverity())
+		}
+		if vr.Setting == "topologyManagerPolicy" && vr.GetSeverity() != SeverityInfo {
+			t.Errorf("drift on an updating pool should be informational, got %v", vr.GetSeverity())
+		}
+	}
+
+	kubeletVR := vd.Results()[0]
+	if kubeletVR.Target != "KubeletConfig/tas-worker" {
+		t.Errorf("kubelet finding not attributed to the KubeletConfig: %q", kubeletVR.Target)
+	}
+}
+
+func makeOpenShiftNode(name string, nodeLabels map[string]string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: nodeLabels,
+		},
+	}
+}
+
+func makePool(name, roleLabel string, updating bool) machineconfigv1.MachineConfigPool {
+	mcp := machineconfigv1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				machineconfigv1.KubeletConfigRoleLabelPrefix + name: "",
+			},
+		},
+		Spec: machineconfigv1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{roleLabel: ""},
+			},
+		},
+		Status: machineconfigv1.MachineConfigPoolStatus{
+			MachineCount:        1,
+			UpdatedMachineCount: 1,
+		},
+	}
+	if updating {
+		mcp.Status.UpdatedMachineCount = 0
+		mcp.Status.Conditions = []machineconfigv1.MachineConfigPoolCondition{
+			{
+				Type:   machineconfigv1.MachineConfigPoolUpdating,
+				Status: corev1.ConditionTrue,
+			},
+		}
+	}
+	return mcp
+}
+
+func makeKubeletConfig(name, pool, conf, profile string) machineconfigv1.KubeletConfig {
+	kc := machineconfigv1.KubeletConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: machineconfigv1.KubeletConfigSpec{
+			MachineConfigPoolSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{machineconfigv1.KubeletConfigRoleLabelPrefix + pool: ""},
+			},
+			KubeletConfig: &runtime.RawExtension{
+				Raw: []byte(conf),
+			},
+		},
+	}
+	if profile != "" {
+		kc.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: "performance.openshift.io/v2",
+				Kind:       KindPerformanceProfile,
+				Name:       profile,
+			},
+		}
+	}
+	return kc
+}
@@ -0,0 +1,404 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	"sigs.k8s.io/yaml"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+func ParseSeverity(sev string) (Severity, bool) {
+	switch strings.ToLower(sev) {
+	case "", string(SeverityError):
+		return SeverityError, true
+	case string(SeverityWarning):
+		return SeverityWarning, true
+	case string(SeverityInfo):
+		return SeverityInfo, true
+	default:
+		return SeverityError, false
+	}
+}
+
+const (
+	PolicyStrict     = "strict"
+	PolicyRestricted = "restricted"
+	PolicyCPUOnly    = "cpu-only"
+)
+
+const (
+	DefaultPolicyName = PolicyStrict
+)
+
+// ValueRule accepts a setting if its value is one of Allowed.
+// An empty Allowed list disables the check.
+type ValueRule struct {
+	Allowed  []string `json:"allowed,omitempty"`
+	Severity Severity `json:"severity,omitempty"`
+}
+
+func (vr ValueRule) IsEnabled() bool {
+	return len(vr.Allowed) > 0
+}
+
+func (vr ValueRule) Accepts(value string) bool {
+	for _, allowed := range vr.Allowed {
+		if value == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func (vr ValueRule) Expected() string {
+	if len(vr.Allowed) == 1 {
+		return vr.Allowed[0]
+	}
+	return "one of " + strings.Join(vr.Allowed, ",")
+}
+
+// RangeRule accepts a duration setting if it falls in [Min, Max].
+// A zero Max means no upper bound; if both are zero, the check is disabled.
+type RangeRule struct {
+	Min      metav1.Duration `json:"min,omitempty"`
+	Max      metav1.Duration `json:"max,omitempty"`
+	Severity Severity        `json:"severity,omitempty"`
+}
+
+func (rr RangeRule) IsEnabled() bool {
+	return rr.Min.Duration > 0 || rr.Max.Duration > 0
+}
+
+func (rr RangeRule) Accepts(value time.Duration) bool {
+	if value < rr.Min.Duration {
+		return false
+	}
+	if rr.Max.Duration > 0 && value > rr.Max.Duration {
+		return false
+	}
+	return true
+}
+
+func (rr RangeRule) Expected() string {
+	if rr.Max.Duration == 0 {
+		return fmt.Sprintf("at least %v", rr.Min.Duration)
+	}
+	return fmt.Sprintf("in range [%v, %v]", rr.Min.Duration, rr.Max.Duration)
+}
+
+// PresenceRule requires a setting to be set to any non-empty value.
+type PresenceRule struct {
+	Required bool     `json:"required,omitempty"`
+	Severity Severity `json:"severity,omitempty"`
+}
+
+func (pr PresenceRule) IsEnabled() bool {
+	return pr.Required
+}
+
+// OptionsRule requires policy options to be set to the given values; an empty value accepts any value.
+// If CheckSupported is true, options unknown or unsupported by the node kubelet version are rejected.
+type OptionsRule struct {
+	Required       map[string]string `json:"required,omitempty"`
+	CheckSupported bool              `json:"checkSupported,omitempty"`
+	Severity       Severity          `json:"severity,omitempty"`
+}
+
+func (or OptionsRule) IsEnabled() bool {
+	return len(or.Required) > 0 || or.CheckSupported
+}
+
+// ToggleRule enables a check which has no parameters.
+type ToggleRule struct {
+	Enabled  bool     `json:"enabled,omitempty"`
+	Severity Severity `json:"severity,omitempty"`
+}
+
+func (tr ToggleRule) IsEnabled() bool {
+	return tr.Enabled
+}
+
+// Policy describes the acceptable kubelet configuration for a cluster.
+type Policy struct {
+	Name                         string       `json:"name"`
+	CPUManagerPolicy             ValueRule    `json:"cpuManagerPolicy,omitempty"`
+	CPUManagerPolicyOptions      OptionsRule  `json:"cpuManagerPolicyOptions,omitempty"`
+	CPUManagerReconcilePeriod    RangeRule    `json:"cpuManagerReconcilePeriod,omitempty"`
+	ReservedSystemCPUs           PresenceRule `json:"reservedSystemCPUs,omitempty"`
+	MemoryManagerPolicy          ValueRule    `json:"memoryManagerPolicy,omitempty"`
+	ReservedMemory               PresenceRule `json:"reservedMemory,omitempty"`
+	ReservedMemoryConsistency    ToggleRule   `json:"reservedMemoryConsistency,omitempty"`
+	TopologyManagerPolicy        ValueRule    `json:"topologyManagerPolicy,omitempty"`
+	TopologyManagerPolicyOptions OptionsRule  `json:"topologyManagerPolicyOptions,omitempty"`
+	TopologyManagerScope         ValueRule    `json:"topologyManagerScope,omitempty"`
+}
+
+func (pol Policy) Validate() error {
+	for name, sev := range map[string]Severity{
+		"cpuManagerPolicy":             pol.CPUManagerPolicy.Severity,
+		"cpuManagerPolicyOptions":      pol.CPUManagerPolicyOptions.Severity,
+		"cpuManagerReconcilePeriod":    pol.CPUManagerReconcilePeriod.Severity,
+		"reservedSystemCPUs":           pol.ReservedSystemCPUs.Severity,
+		"memoryManagerPolicy":          pol.MemoryManagerPolicy.Severity,
+		"reservedMemory":               pol.ReservedMemory.Severity,
+		"reservedMemoryConsistency":    pol.ReservedMemoryConsistency.Severity,
+		"topologyManagerPolicy":        pol.TopologyManagerPolicy.Severity,
+		"topologyManagerPolicyOptions": pol.TopologyManagerPolicyOptions.Severity,
+		"topologyManagerScope":         pol.TopologyManagerScope.Severity,
+	} {
+		if _, ok := ParseSeverity(string(sev)); !ok {
+			return fmt.Errorf("policy %q: rule %q: unsupported severity %q", pol.Name, name, sev)
+		}
+	}
+	rr := pol.CPUManagerReconcilePeriod // shortcut
+	if rr.Max.Duration > 0 && rr.Min.Duration > rr.Max.Duration {
+		return fmt.Errorf("policy %q: rule %q: min %v greater than max %v", pol.Name, "cpuManagerReconcilePeriod", rr.Min.Duration, rr.Max.Duration)
+	}
+	return nil
+}
+
+// KubeletRules returns the IDs of the kubelet configuration rules enabled by the policy.
+func (pol Policy) KubeletRules() []string {
+	var ret []string
+	for _, item := range []struct {
+		ruleID  string
+		enabled bool
+	}{
+		{RuleCPUManagerPolicy, pol.CPUManagerPolicy.IsEnabled()},
+		{RuleCPUManagerPolicyOptions, pol.CPUManagerPolicyOptions.IsEnabled()},
+		{RuleCPUManagerReconcilePeriod, pol.CPUManagerReconcilePeriod.IsEnabled()},
+		{RuleReservedSystemCPUs, pol.ReservedSystemCPUs.IsEnabled()},
+		{RuleMemoryManagerPolicy, pol.MemoryManagerPolicy.IsEnabled()},
+		{RuleReservedMemory, pol.ReservedMemory.IsEnabled()},
+		{RuleReservedMemoryConsistency, pol.ReservedMemoryConsistency.IsEnabled()},
+		{RuleTopologyManagerPolicy, pol.TopologyManagerPolicy.IsEnabled()},
+		{RuleTopologyManagerPolicyOptions, pol.TopologyManagerPolicyOptions.IsEnabled()},
+		{RuleTopologyManagerScope, pol.TopologyManagerScope.IsEnabled()},
+	} {
+		if item.enabled {
+			ret = append(ret, item.ruleID)
+		}
+	}
+	return ret
+}
+
+func severityRank(sev Severity) int {
+	switch sev {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func severityOrDefault(sev Severity) Severity {
+	ret, _ := ParseSeverity(string(sev))
+	return ret
+}
+
+func StrictPolicy() Policy {
+	return Policy{
+		Name: PolicyStrict,
+		CPUManagerPolicy: ValueRule{
+			Allowed:  []string{ExpectedCPUManagerPolicy},
+			Severity: SeverityError,
+		},
+		CPUManagerPolicyOptions: OptionsRule{
+			CheckSupported: true,
+			Severity:       SeverityError,
+		},
+		CPUManagerReconcilePeriod: RangeRule{
+			Min:      metav1.Duration{Duration: CPUManagerReconcilePeriodMin},
+			Max:      metav1.Duration{Duration: CPUManagerReconcilePeriodMax},
+			Severity: SeverityError,
+		},
+		ReservedSystemCPUs: PresenceRule{
+			Required: true,
+			Severity: SeverityError,
+		},
+		MemoryManagerPolicy: ValueRule{
+			Allowed:  []string{ExpectedMemoryManagerPolicy},
+			Severity: SeverityError,
+		},
+		ReservedMemory: PresenceRule{
+			Required: true,
+			Severity: SeverityError,
+		},
+		ReservedMemoryConsistency: ToggleRule{
+			Enabled:  true,
+			Severity: SeverityError,
+		},
+		TopologyManagerPolicy: ValueRule{
+			Allowed:  []string{ExpectedTopologyManagerPolicy},
+			Severity: SeverityError,
+		},
+		TopologyManagerPolicyOptions: OptionsRule{
+			CheckSupported: true,
+			Severity:       SeverityError,
+		},
+		TopologyManagerScope: ValueRule{
+			Allowed: []string{
+				kubeletconfigv1beta1.ContainerTopologyManagerScope,
+				kubeletconfigv1beta1.PodTopologyManagerScope,
+			},
+			Severity: SeverityError,
+		},
+	}
+}
+
+func RestrictedPolicy() Policy {
+	return Policy{
+		Name: PolicyRestricted,
+		CPUManagerPolicy: ValueRule{
+			Allowed:  []string{ExpectedCPUManagerPolicy},
+			Severity: SeverityError,
+		},
+		CPUManagerPolicyOptions: OptionsRule{
+			CheckSupported: true,
+			Severity:       SeverityError,
+		},
+		CPUManagerReconcilePeriod: RangeRule{
+			Min:      metav1.Duration{Duration: CPUManagerReconcilePeriodMin},
+			Max:      metav1.Duration{Duration: CPUManagerReconcilePeriodMax},
+			Severity: SeverityWarning,
+		},
+		ReservedSystemCPUs: PresenceRule{
+			Required: true,
+			Severity: SeverityError,
+		},
+		MemoryManagerPolicy: ValueRule{
+			Allowed: []string{
+				kubeletconfigv1beta1.NoneMemoryManagerPolicy,
+				kubeletconfigv1beta1.StaticMemoryManagerPolicy,
+			},
+			Severity: SeverityWarning,
+		},
+		TopologyManagerPolicy: ValueRule{
+			Allowed: []string{
+				kubeletconfigv1beta1.RestrictedTopologyManagerPolicy,
+				kubeletconfigv1beta1.SingleNumaNodeTopologyManagerPolicy,
+			},
+			Severity: SeverityError,
+		},
+		TopologyManagerScope: ValueRule{
+			Allowed: []string{
+				kubeletconfigv1beta1.ContainerTopologyManagerScope,
+				kubeletconfigv1beta1.PodTopologyManagerScope,
+			},
+			Severity: SeverityError,
+		},
+		TopologyManagerPolicyOptions: OptionsRule{
+			CheckSupported: true,
+			Severity:       SeverityError,
+		},
+		ReservedMemoryConsistency: ToggleRule{
+			Enabled:  true,
+			Severity: SeverityError,
+		},
+	}
+}
+
+func CPUOnlyPolicy() Policy {
+	return Policy{
+		Name: PolicyCPUOnly,
+		CPUManagerPolicy: ValueRule{
+			Allowed:  []string{ExpectedCPUManagerPolicy},
+			Severity: SeverityError,
+		},
+		CPUManagerPolicyOptions: OptionsRule{
+			CheckSupported: true,
+			Severity:       SeverityError,
+		},
+		CPUManagerReconcilePeriod: RangeRule{
+			Min:      metav1.Duration{Duration: CPUManagerReconcilePeriodMin},
+			Max:      metav1.Duration{Duration: CPUManagerReconcilePeriodMax},
+			Severity: SeverityWarning,
+		},
+		ReservedSystemCPUs: PresenceRule{
+			Required: true,
+			Severity: SeverityError,
+		},
+		TopologyManagerPolicy: ValueRule{
+			Allowed: []string{
+				kubeletconfigv1beta1.BestEffortTopologyManagerPolicy,
+				kubeletconfigv1beta1.RestrictedTopologyManagerPolicy,
+				kubeletconfigv1beta1.SingleNumaNodeTopologyManagerPolicy,
+			},
+			Severity: SeverityWarning,
+		},
+		TopologyManagerPolicyOptions: OptionsRule{
+			CheckSupported: true,
+			Severity:       SeverityError,
+		},
+	}
+}
+
+var profiles = map[string]func() Policy{
+	PolicyStrict:     StrictPolicy,
+	PolicyRestricted: RestrictedPolicy,
+	PolicyCPUOnly:    CPUOnlyPolicy,
+}
+
+// ProfileNames returns the sorted names of the builtin policies
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetProfile returns the builtin policy with the given name
+func GetProfile(name string) (Policy, bool) {
+	makePolicy, ok := profiles[strings.ToLower(name)]
+	if !ok {
+		return Policy{}, false
+	}
+	return makePolicy(), true
+}
+
+// DecodePolicy decodes a user-supplied policy, either in YAML or JSON format.
+func DecodePolicy(data []byte) (Policy, error) {
+	pol := Policy{}
+	if err := yaml.UnmarshalStrict(data, &pol); err != nil {
+		return pol, err
+	}
+	if pol.Name == "" {
+		pol.Name = "custom"
+	}
+	return pol, pol.Validate()
+}
@@ -0,0 +1,226 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+func TestBuiltinProfiles(t *testing.T) {
+	for _, name := range ProfileNames() {
+		t.Run(name, func(t *testing.T) {
+			pol, ok := GetProfile(name)
+			if !ok {
+				t.Fatalf("missing builtin profile %q", name)
+			}
+			if pol.Name != name {
+				t.Errorf("profile name mismatch: got %q expected %q", pol.Name, name)
+			}
+			if err := pol.Validate(); err != nil {
+				t.Errorf("invalid builtin profile %q: %v", name, err)
+			}
+		})
+	}
+
+	if _, ok := GetProfile("foobar"); ok {
+		t.Errorf("unexpected profile found for unknown name")
+	}
+}
+
+func TestDecodePolicy(t *testing.T) {
+	type testCase struct {
+		name          string
+		data          string
+		expectedError bool
+		expectedName  string
+	}
+
+	testCases := []testCase{
+		{
+			name:         "empty",
+			data:         "",
+			expectedName: "custom",
+		},
+		{
+			name: "full",
+			data: `name: production
+cpuManagerPolicy:
+  allowed: ["static"]
+cpuManagerReconcilePeriod:
+  min: 1s
+  max: 10s
+  severity: warning
+topologyManagerPolicy:
+  allowed: ["restricted"]
+topologyManagerScope:
+  allowed: ["pod"]
+  severity: error
+`,
+			expectedName: "production",
+		},
+		{
+			name: "unknown severity",
+			data: `cpuManagerPolicy:
+  allowed: ["static"]
+  severity: fatal
+`,
+			expectedError: true,
+		},
+		{
+			name: "unknown field",
+			data: `cpuManagerPolicy:
+  expected: static
+`,
+			expectedError: true,
+		},
+		{
+			name: "inverted range",
+			data: `cpuManagerReconcilePeriod:
+  min: 10s
+  max: 1s
+`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pol, err := DecodePolicy([]byte(tc.data))
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pol.Name != tc.expectedName {
+				t.Errorf("name mismatch: got %q expected %q", pol.Name, tc.expectedName)
+			}
+		})
+	}
+}
+
+func TestKubeletValidationsWithPolicy(t *testing.T) {
+	nodeName := "testNode"
+
+	// restricted topology manager policy, pod scope, no memory manager
+	restrictedConf := &kubeletconfigv1beta1.KubeletConfiguration{
+		CPUManagerPolicy: ExpectedCPUManagerPolicy,
+		CPUManagerReconcilePeriod: metav1.Duration{
+			Duration: 5 * time.Second,
+		},
+		MemoryManagerPolicy:   kubeletconfigv1beta1.NoneMemoryManagerPolicy,
+		ReservedSystemCPUs:    "0,1",
+		TopologyManagerPolicy: kubeletconfigv1beta1.RestrictedTopologyManagerPolicy,
+		TopologyManagerScope:  kubeletconfigv1beta1.PodTopologyManagerScope,
+	}
+
+	type testCase struct {
+		name        string
+		policy      Policy
+		kubeletConf *kubeletconfigv1beta1.KubeletConfiguration
+		expected    []ValidationResult
+	}
+
+	testCases := []testCase{
+		{
+			name:        "strict",
+			policy:      StrictPolicy(),
+			kubeletConf: restrictedConf,
+			expected: []ValidationResult{
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentMemoryManager,
+					Setting:   "policy",
+				},
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentConfiguration,
+					Setting:   "memory",
+				},
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentTopologyManager,
+					Setting:   "policy",
+				},
+			},
+		},
+		{
+			name:        "restricted",
+			policy:      RestrictedPolicy(),
+			kubeletConf: restrictedConf,
+			expected:    []ValidationResult{},
+		},
+		{
+			name:        "cpu-only",
+			policy:      CPUOnlyPolicy(),
+			kubeletConf: restrictedConf,
+			expected:    []ValidationResult{},
+		},
+		{
+			name:   "restricted, wrong scope",
+			policy: RestrictedPolicy(),
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{
+				CPUManagerPolicy: ExpectedCPUManagerPolicy,
+				CPUManagerReconcilePeriod: metav1.Duration{
+					Duration: 5 * time.Second,
+				},
+				ReservedSystemCPUs:    "0,1",
+				TopologyManagerPolicy: kubeletconfigv1beta1.RestrictedTopologyManagerPolicy,
+				TopologyManagerScope:  "node",
+			},
+			expected: []ValidationResult{
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentMemoryManager,
+					Setting:   "policy",
+				},
+				{
+					Node:      nodeName,
+					Area:      AreaKubelet,
+					Component: ComponentTopologyManager,
+					Setting:   "scope",
+				},
+			},
+		},
+		{
+			name:        "empty policy",
+			policy:      Policy{},
+			kubeletConf: &kubeletconfigv1beta1.KubeletConfiguration{},
+			expected:    []ValidationResult{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ValidateClusterNodeKubeletConfigWithPolicy(nodeName, nil, tc.kubeletConf, tc.policy)
+			if !matchValidationResults(tc.expected, got) {
+				t.Fatalf("validation failed:\nexpected=%#v\ngot=%#v", tc.expected, got)
+			}
+		})
+	}
+}
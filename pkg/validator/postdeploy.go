@@ -0,0 +1,376 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nrtv1alpha2 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha2"
+	topologyclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/images"
+	"github.com/k8stopologyawareschedwg/deployer/pkg/manifests"
+)
+
+const (
+	AreaDeployment = "deployment"
+)
+
+const (
+	ComponentNRT             = "NodeResourceTopology"
+	ComponentUpdater         = "topology updater"
+	ComponentSchedulerConfig = "scheduler configuration"
+	ComponentTestPod         = "test pod"
+)
+
+const (
+	// NUMA zones in NRT objects are expected to have this type
+	nrtZoneTypeNode = "Node"
+	// updater containers which terminated more recently than this are considered restarting
+	updaterRestartWindow = 10 * time.Minute
+)
+
+const (
+	DefaultTestPodImage     = images.PauseDefaultImageTag
+	DefaultTestPodNamespace = "default"
+)
+
+type PostDeployOptions struct {
+	// UpdaterNamespace and UpdaterSelector locate the updater pods. Empty namespace disables the liveness check.
+	UpdaterNamespace string
+	UpdaterSelector  map[string]string
+	// SchedulerConfigMap locates the scheduler configuration. Empty name disables the check.
+	SchedulerConfigMap   client.ObjectKey
+	SchedulerProfileName string
+	// TestPod, if not nil, is created and expected to be scheduled and running.
+	TestPod     *corev1.Pod
+	WaitTimeout time.Duration
+}
+
+// ValidateNRTs checks every node has a NRT object, which looks plausible compared to the node status.
+func ValidateNRTs(nodes []corev1.Node, nrts []nrtv1alpha2.NodeResourceTopology) []ValidationResult {
+	vrs := []ValidationResult{}
+	nrtByName := make(map[string]nrtv1alpha2.NodeResourceTopology)
+	for _, nrt := range nrts {
+		nrtByName[nrt.Name] = nrt
+	}
+
+	for _, node := range nodes {
+		nrt, ok := nrtByName[node.Name]
+		if !ok {
+			vrs = append(vrs, ValidationResult{
+				Node:        node.Name,
+				Area:        AreaDeployment,
+				Component:   ComponentNRT,
+				Setting:     "presence",
+				Expected:    "present",
+				Detected:    "missing",
+				Severity:    SeverityError,
+				RuleID:      RuleNRTPresent,
+				Remediation: Remediation(RuleNRTPresent),
+			})
+			continue
+		}
+
+		if issue := checkNRTZones(node, nrt); issue != "" {
+			vrs = append(vrs, ValidationResult{
+				Node:        node.Name,
+				Area:        AreaDeployment,
+				Component:   ComponentNRT,
+				Setting:     "zones",
+				Expected:    "at least one NUMA zone consistent with node capacity",
+				Detected:    issue,
+				Severity:    SeverityError,
+				RuleID:      RuleNRTZones,
+				Remediation: Remediation(RuleNRTZones),
+			})
+		}
+	}
+	return vrs
+}
+
+// ValidateUpdaterPods checks every node runs an updater pod which is ready and not restarting, so the NRT object
+// of the node is kept up to date. The NRT objects themselves can't tell: the API server drops the writes which
+// don't change the object, so on an idle node a healthy updater leaves it untouched.
+func ValidateUpdaterPods(nodes []corev1.Node, pods []corev1.Pod, now time.Time) []ValidationResult {
+	vrs := []ValidationResult{}
+	podByNode := make(map[string]corev1.Pod)
+	for _, pod := range pods {
+		podByNode[pod.Spec.NodeName] = pod
+	}
+
+	for _, node := range nodes {
+		detected := ""
+		pod, ok := podByNode[node.Name]
+		if !ok {
+			detected = "missing"
+		} else {
+			detected = checkUpdaterPod(pod, now)
+		}
+		if detected == "" {
+			continue
+		}
+		vrs = append(vrs, ValidationResult{
+			Node:        node.Name,
+			Area:        AreaDeployment,
+			Component:   ComponentUpdater,
+			Setting:     "pod",
+			Expected:    "ready and not restarting",
+			Detected:    detected,
+			Severity:    SeverityError,
+			RuleID:      RuleUpdaterAlive,
+			Remediation: Remediation(RuleUpdaterAlive),
+		})
+	}
+	return vrs
+}
+
+// ValidateSchedulerConfigData checks the scheduler configuration decodes and contains the expected profile.
+func ValidateSchedulerConfigData(data []byte, profileName string) []ValidationResult {
+	vrs := []ValidationResult{}
+	params, err := manifests.DecodeSchedulerProfilesFromData(data)
+	detected := ""
+	if err != nil {
+		detected = err.Error()
+	} else if len(params) == 0 {
+		detected = "no profiles"
+	} else if profileName != "" && manifests.FindSchedulerProfileByName(params, profileName) == nil {
+		detected = fmt.Sprintf("missing profile %q", profileName)
+	}
+	if detected != "" {
+		vrs = append(vrs, ValidationResult{
+			Area:        AreaDeployment,
+			Component:   ComponentSchedulerConfig,
+			Setting:     "profiles",
+			Expected:    fmt.Sprintf("profile %q", profileName),
+			Detected:    detected,
+			Severity:    SeverityError,
+			RuleID:      RuleSchedulerConfig,
+			Remediation: Remediation(RuleSchedulerConfig),
+		})
+	}
+	return vrs
+}
+
+func (vd *Validator) ValidatePostDeploy(ctx context.Context, cli client.Client, topoCli topologyclientset.Interface, nodes []corev1.Node, opts PostDeployOptions) ([]ValidationResult, error) {
+	nrtList, err := topoCli.TopologyV1alpha2().NodeResourceTopologies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	vrs := []ValidationResult{}
+	for _, node := range nodes {
+		vd.checks = append(vd.checks,
+			Check{Node: node.Name, Area: AreaDeployment, RuleID: RuleNRTPresent},
+			Check{Node: node.Name, Area: AreaDeployment, RuleID: RuleNRTZones},
+		)
+		if opts.UpdaterNamespace != "" {
+			vd.checks = append(vd.checks, Check{Node: node.Name, Area: AreaDeployment, RuleID: RuleUpdaterAlive})
+		}
+	}
+	vrs = append(vrs, ValidateNRTs(nodes, nrtList.Items)...)
+
+	if opts.UpdaterNamespace != "" {
+		podList := corev1.PodList{}
+		if err := cli.List(ctx, &podList, client.InNamespace(opts.UpdaterNamespace), client.MatchingLabels(opts.UpdaterSelector)); err != nil {
+			return nil, err
+		}
+		vrs = append(vrs, ValidateUpdaterPods(nodes, podList.Items, time.Now())...)
+	}
+
+	if opts.SchedulerConfigMap.Name != "" {
+		vd.checks = append(vd.checks, Check{Area: AreaDeployment, RuleID: RuleSchedulerConfig})
+		cm := corev1.ConfigMap{}
+		if err := cli.Get(ctx, opts.SchedulerConfigMap, &cm); err != nil {
+			vrs = append(vrs, ValidationResult{
+				Area:        AreaDeployment,
+				Component:   ComponentSchedulerConfig,
+				Setting:     "configmap",
+				Expected:    opts.SchedulerConfigMap.String(),
+				Detected:    err.Error(),
+				Severity:    SeverityError,
+				RuleID:      RuleSchedulerConfig,
+				Remediation: Remediation(RuleSchedulerConfig),
+			})
+		} else {
+			vrs = append(vrs, ValidateSchedulerConfigData([]byte(cm.Data[manifests.SchedulerConfigFileName]), opts.SchedulerProfileName)...)
+		}
+	}
+
+	if opts.TestPod != nil {
+		vd.checks = append(vd.checks, Check{Area: AreaDeployment, RuleID: RuleTestPod})
+		vrs = append(vrs, vd.runTestPod(ctx, cli, opts.TestPod, opts.WaitTimeout)...)
+	}
+
+	vd.results = append(vd.results, vrs...)
+	return vrs, nil
+}
+
+func (vd *Validator) runTestPod(ctx context.Context, cli client.Client, pod *corev1.Pod, timeout time.Duration) []ValidationResult {
+	failed := func(detected string) []ValidationResult {
+		return []ValidationResult{
+			{
+				Area:        AreaDeployment,
+				Component:   ComponentTestPod,
+				Setting:     pod.Spec.SchedulerName,
+				Expected:    "scheduled and running",
+				Detected:    detected,
+				Severity:    SeverityError,
+				RuleID:      RuleTestPod,
+				Remediation: Remediation(RuleTestPod),
+			},
+		}
+	}
+
+	testPod := pod.DeepCopy()
+	if err := cli.Create(ctx, testPod); err != nil {
+		return failed(fmt.Sprintf("creation failed: %v", err))
+	}
+	vd.Log.Info("created test pod", "namespace", testPod.Namespace, "name", testPod.Name)
+	defer func() {
+		if err := cli.Delete(ctx, testPod); err != nil {
+			vd.Log.Info("failed to delete test pod", "namespace", testPod.Namespace, "name", testPod.Name, "error", err)
+		}
+	}()
+
+	key := client.ObjectKeyFromObject(testPod)
+	updatedPod := &corev1.Pod{}
+	err := k8swait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(fctx context.Context) (bool, error) {
+		if err := cli.Get(fctx, key, updatedPod); err != nil {
+			return false, err
+		}
+		if reason := testPodFailure(updatedPod); reason != "" {
+			return false, fmt.Errorf("%s", reason)
+		}
+		return updatedPod.Status.Phase == corev1.PodRunning, nil
+	})
+	if err != nil {
+		if reason := testPodPendingReason(updatedPod); reason != "" {
+			err = fmt.Errorf("%w: %s", err, reason)
+		}
+		return failed(fmt.Sprintf("phase %q node %q: %v", updatedPod.Status.Phase, updatedPod.Spec.NodeName, err))
+	}
+	vd.Log.Info("test pod running", "namespace", testPod.Namespace, "name", testPod.Name, "node", updatedPod.Spec.NodeName)
+	return []ValidationResult{}
+}
+
+// NewTestPod returns a guaranteed QoS pod, requiring exclusive CPUs, to be scheduled by the given scheduler
+func NewTestPod(namespace, schedulerName, image string) *corev1.Pod {
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("256Mi"),
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "tas-validate-",
+			Namespace:    namespace,
+		},
+		Spec: corev1.PodSpec{
+			SchedulerName: schedulerName,
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:            "test",
+					Image:           image,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Resources: corev1.ResourceRequirements{
+						Limits:   resources,
+						Requests: resources,
+					},
+				},
+			},
+		},
+	}
+}
+
+// testPodFailure returns why the test pod can't become running, empty if it still can.
+func testPodFailure(pod *corev1.Pod) string {
+	if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+		return fmt.Sprintf("terminated: %s %s", pod.Status.Reason, pod.Status.Message)
+	}
+	return ""
+}
+
+// testPodPendingReason returns why the test pod is not scheduled yet, empty if unknown. Unschedulable pods are
+// retried by the scheduler, for example once the NRT objects are updated, so this is not a failure by itself.
+func testPodPendingReason(pod *corev1.Pod) string {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse && cond.Reason == corev1.PodReasonUnschedulable {
+			return fmt.Sprintf("unschedulable: %s", cond.Message)
+		}
+	}
+	return ""
+}
+
+// checkUpdaterPod returns why the updater pod is not ready or is restarting, empty if it is healthy.
+func checkUpdaterPod(pod corev1.Pod, now time.Time) string {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil {
+			return fmt.Sprintf("container %q waiting: %s", cs.Name, cs.State.Waiting.Reason)
+		}
+		term := cs.LastTerminationState.Terminated
+		if term != nil && now.Sub(term.FinishedAt.Time) < updaterRestartWindow {
+			return fmt.Sprintf("container %q restarted %d times, last %v ago", cs.Name, cs.RestartCount, now.Sub(term.FinishedAt.Time).Round(time.Second))
+		}
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+			return ""
+		}
+	}
+	return fmt.Sprintf("pod %q not ready, phase %q", pod.Name, pod.Status.Phase)
+}
+
+// checkNRTZones returns a description of the problems found in the NRT zones, empty if none.
+func checkNRTZones(node corev1.Node, nrt nrtv1alpha2.NodeResourceTopology) string {
+	numaZones := 0
+	zonesCPUs := resource.NewQuantity(0, resource.DecimalSI)
+	for _, zone := range nrt.Zones {
+		if zone.Type != nrtZoneTypeNode {
+			continue
+		}
+		numaZones++
+		for _, res := range zone.Resources {
+			if res.Name == string(corev1.ResourceCPU) {
+				zonesCPUs.Add(res.Capacity)
+			}
+		}
+	}
+	if numaZones == 0 {
+		return fmt.Sprintf("no NUMA zones out of %d zones", len(nrt.Zones))
+	}
+	if zonesCPUs.IsZero() {
+		return fmt.Sprintf("%d NUMA zones, no CPUs reported", numaZones)
+	}
+	nodeCPUs, ok := node.Status.Capacity[corev1.ResourceCPU]
+	if ok && zonesCPUs.Cmp(nodeCPUs) > 0 {
+		return fmt.Sprintf("%d NUMA zones with %s CPUs, more than node capacity %s", numaZones, zonesCPUs.String(), nodeCPUs.String())
+	}
+	if ok && int64(numaZones) > nodeCPUs.Value() {
+		return fmt.Sprintf("%d NUMA zones, more than node CPUs %s", numaZones, nodeCPUs.String())
+	}
+	return ""
+}
@@ -0,0 +1,345 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nrtv1alpha2 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha2"
+)
+
+const testSchedulerConfig = `apiVersion: kubescheduler.config.k8s.io/v1beta3
+kind: KubeSchedulerConfiguration
+leaderElection:
+  leaderElect: false
+profiles:
+- schedulerName: topology-aware-scheduler
+  plugins:
+    filter:
+      enabled:
+        - name: NodeResourceTopologyMatch
+  pluginConfig:
+  - name: NodeResourceTopologyMatch
+    args: {}
+`
+
+func TestValidateNRTs(t *testing.T) {
+	makeNode := func(name, cpus string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse(cpus),
+				},
+			},
+		}
+	}
+	makeNRT := func(name string, zoneCPUs ...string) nrtv1alpha2.NodeResourceTopology {
+		nrt := nrtv1alpha2.NodeResourceTopology{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}
+		for _, cpus := range zoneCPUs {
+			nrt.Zones = append(nrt.Zones, nrtv1alpha2.Zone{
+				Name: "node-x",
+				Type: "Node",
+				Resources: nrtv1alpha2.ResourceInfoList{
+					{
+						Name:     "cpu",
+						Capacity: resource.MustParse(cpus),
+					},
+				},
+			})
+		}
+		return nrt
+	}
+
+	type testCase struct {
+		name     string
+		nodes    []corev1.Node
+		nrts     []nrtv1alpha2.NodeResourceTopology
+		expected []ValidationResult
+	}
+
+	testCases := []testCase{
+		{
+			name:     "all good",
+			nodes:    []corev1.Node{makeNode("node-0", "8"), makeNode("node-1", "8")},
+			nrts:     []nrtv1alpha2.NodeResourceTopology{makeNRT("node-0", "4", "4"), makeNRT("node-1", "8")},
+			expected: []ValidationResult{},
+		},
+		{
+			name:  "missing NRT",
+			nodes: []corev1.Node{makeNode("node-0", "8"), makeNode("node-1", "8")},
+			nrts:  []nrtv1alpha2.NodeResourceTopology{makeNRT("node-0", "4", "4")},
+			expected: []ValidationResult{
+				{Node: "node-1", Area: AreaDeployment, Component: ComponentNRT, Setting: "presence"},
+			},
+		},
+		{
+			name:  "no zones",
+			nodes: []corev1.Node{makeNode("node-0", "8")},
+			nrts:  []nrtv1alpha2.NodeResourceTopology{makeNRT("node-0")},
+			expected: []ValidationResult{
+				{Node: "node-0", Area: AreaDeployment, Component: ComponentNRT, Setting: "zones"},
+			},
+		},
+		{
+			name:  "zones exceed node capacity",
+			nodes: []corev1.Node{makeNode("node-0", "8")},
+			nrts:  []nrtv1alpha2.NodeResourceTopology{makeNRT("node-0", "8", "8")},
+			expected: []ValidationResult{
+				{Node: "node-0", Area: AreaDeployment, Component: ComponentNRT, Setting: "zones"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ValidateNRTs(tc.nodes, tc.nrts)
+			if !matchValidationResults(tc.expected, got) {
+				t.Fatalf("validation failed:\nexpected=%#v\ngot=%#v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateSchedulerConfigData(t *testing.T) {
+	type testCase struct {
+		name          string
+		data          string
+		profileName   string
+		expectedError bool
+	}
+
+	testCases := []testCase{
+		{
+			name:        "good",
+			data:        testSchedulerConfig,
+			profileName: "topology-aware-scheduler",
+		},
+		{
+			name:          "missing profile",
+			data:          testSchedulerConfig,
+			profileName:   "foobar",
+			expectedError: true,
+		},
+		{
+			name:          "garbage",
+			data:          "foo: [",
+			profileName:   "topology-aware-scheduler",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ValidateSchedulerConfigData([]byte(tc.data), tc.profileName)
+			if tc.expectedError != (len(got) > 0) {
+				t.Fatalf("unexpected results: %#v", got)
+			}
+		})
+	}
+}
+
+func TestNewTestPod(t *testing.T) {
+	pod := NewTestPod("test-ns", "topology-aware-scheduler", DefaultTestPodImage)
+	if pod.Namespace != "test-ns" || pod.Spec.SchedulerName != "topology-aware-scheduler" {
+		t.Fatalf("unexpected pod: %#v", pod)
+	}
+	for _, cnt := range pod.Spec.Containers {
+		for resName, qty := range cnt.Resources.Requests {
+			lim, ok := cnt.Resources.Limits[resName]
+			if !ok || !lim.Equal(qty) {
+				t.Errorf("container %q resource %q: requests and limits differ, pod is not guaranteed", cnt.Name, resName)
+			}
+		}
+	}
+}
+
+func TestValidateUpdaterPods(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-0"}},
+	}
+	makePod := func(ready bool, cs corev1.ContainerStatus) corev1.Pod {
+		readyStatus := corev1.ConditionFalse
+		if ready {
+			readyStatus = corev1.ConditionTrue
+		}
+		cs.Name = "tas-topology-updater-container"
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "updater-xyz"},
+			Spec:       corev1.PodSpec{NodeName: "node-0"},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: readyStatus},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{cs},
+			},
+		}
+	}
+	restartedAt := func(ago time.Duration) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			RestartCount: 3,
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					FinishedAt: metav1.NewTime(now.Add(-ago)),
+				},
+			},
+		}
+	}
+
+	type testCase struct {
+		name     string
+		pods     []corev1.Pod
+		expected []ValidationResult
+	}
+
+	testCases := []testCase{
+		{
+			name:     "ready",
+			pods:     []corev1.Pod{makePod(true, corev1.ContainerStatus{})},
+			expected: []ValidationResult{},
+		},
+		{
+			name:     "ready, restarted long ago",
+			pods:     []corev1.Pod{makePod(true, restartedAt(time.Hour))},
+			expected: []ValidationResult{},
+		},
+		{
+			name: "missing",
+			expected: []ValidationResult{
+				{Node: "node-0", Area: AreaDeployment, Component: ComponentUpdater, Setting: "pod"},
+			},
+		},
+		{
+			name: "not ready",
+			pods: []corev1.Pod{makePod(false, corev1.ContainerStatus{})},
+			expected: []ValidationResult{
+				{Node: "node-0", Area: AreaDeployment, Component: ComponentUpdater, Setting: "pod"},
+			},
+		},
+		{
+			name: "ready, restarted recently",
+			pods: []corev1.Pod{makePod(true, restartedAt(time.Minute))},
+			expected: []ValidationResult{
+				{Node: "node-0", Area: AreaDeployment, Component: ComponentUpdater, Setting: "pod"},
+			},
+		},
+		{
+			name: "crash looping",
+			pods: []corev1.Pod{makePod(false, corev1.ContainerStatus{
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+				},
+			})},
+			expected: []ValidationResult{
+				{Node: "node-0", Area: AreaDeployment, Component: ComponentUpdater, Setting: "pod"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ValidateUpdaterPods(nodes, tc.pods, now)
+			if !matchValidationResults(tc.expected, got) {
+				t.Fatalf("validation failed:\nexpected=%#v\ngot=%#v", tc.expected, got)
+			}
+			for _, vr := range got {
+				if vr.Severity != SeverityError || vr.RuleID != RuleUpdaterAlive {
+					t.Errorf("unexpected result: %#v", vr)
+				}
+			}
+		})
+	}
+}
+
+func TestRunTestPodFailure(t *testing.T) {
+	type testCase struct {
+		name           string
+		status         corev1.PodStatus
+		timeout        time.Duration
+		expectedReason string
+	}
+
+	testCases := []testCase{
+		{
+			name: "unschedulable until the timeout",
+			status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:    corev1.PodScheduled,
+						Status:  corev1.ConditionFalse,
+						Reason:  corev1.PodReasonUnschedulable,
+						Message: "0/3 nodes are available: 3 cannot align container",
+					},
+				},
+			},
+			timeout:        500 * time.Millisecond,
+			expectedReason: "cannot align container",
+		},
+		{
+			name: "failed",
+			status: corev1.PodStatus{
+				Phase:  corev1.PodFailed,
+				Reason: "TopologyAffinityError",
+			},
+			timeout:        time.Minute,
+			expectedReason: "TopologyAffinityError",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := NewTestPod("default", "topology-aware-scheduler", DefaultTestPodImage)
+			pod.Name = "tas-validate-test"
+			pod.Status = tc.status
+			vd := Validator{Log: testr.New(t)}
+			cli := fake.NewClientBuilder().Build()
+
+			start := time.Now()
+			vrs := vd.runTestPod(context.TODO(), cli, pod, tc.timeout)
+			elapsed := time.Since(start)
+			if tc.status.Phase == corev1.PodPending && elapsed < tc.timeout {
+				t.Errorf("pending test pod reported as failed before the timeout: %v", elapsed)
+			}
+			if tc.status.Phase == corev1.PodFailed && elapsed > 10*time.Second {
+				t.Errorf("test pod wait did not stop early: %v", elapsed)
+			}
+			if len(vrs) != 1 || vrs[0].RuleID != RuleTestPod {
+				t.Fatalf("unexpected results: %#v", vrs)
+			}
+			if !strings.Contains(vrs[0].Detected, tc.expectedReason) {
+				t.Errorf("reason %q missing from %q", tc.expectedReason, vrs[0].Detected)
+			}
+		})
+	}
+}
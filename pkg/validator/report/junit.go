@@ -0,0 +1,117 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package report
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/validator"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnit writes the validation outcome as JUnit XML report. Each node is a test suite,
+// each rule checked against it is a test case. Only findings with error severity fail
+// the test case, the others are reported in the test case output.
+func JUnit(w io.Writer, checks []validator.Check, results []validator.ValidationResult) error {
+	report := junitTestSuites{
+		Name: "deployer validate",
+	}
+	for _, suite := range Collect(checks, results) {
+		ts := junitTestSuite{
+			Name: suite.Name,
+		}
+		for _, oc := range suite.Outcomes {
+			tc := junitTestCase{
+				Name:      checkName(oc.Check),
+				ClassName: suite.Name + "." + oc.Check.Area,
+			}
+			var notes []string
+			for _, res := range oc.Findings {
+				if res.GetSeverity() != validator.SeverityError {
+					notes = append(notes, describe(res))
+					continue
+				}
+				if tc.Failure == nil {
+					tc.Failure = &junitFailure{
+						Message: res.String(),
+						Type:    string(res.GetSeverity()),
+						Text:    res.Remediation,
+					}
+				} else {
+					notes = append(notes, describe(res))
+				}
+			}
+			tc.SystemOut = strings.Join(notes, "\n")
+			if tc.Failure != nil {
+				ts.Failures++
+			}
+			ts.Tests++
+			ts.TestCases = append(ts.TestCases, tc)
+		}
+		report.Tests += ts.Tests
+		report.Failures += ts.Failures
+		report.Suites = append(report.Suites, ts)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func describe(res validator.ValidationResult) string {
+	desc := strings.ToUpper(string(res.GetSeverity())) + ": " + res.String()
+	if res.Remediation != "" {
+		desc += " (hint: " + res.Remediation + ")"
+	}
+	return desc
+}
@@ -0,0 +1,96 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package report
+
+import (
+	"github.com/k8stopologyawareschedwg/deployer/pkg/validator"
+)
+
+const (
+	// SuiteCluster is the name used to group the cluster-wide checks
+	SuiteCluster = "cluster"
+)
+
+// Outcome pairs a check with the findings it produced, if any.
+type Outcome struct {
+	Check    validator.Check
+	Findings []validator.ValidationResult
+}
+
+// Failed returns true if any finding has error severity.
+func (oc Outcome) Failed() bool {
+	return validator.HasAtLeast(oc.Findings, validator.SeverityError)
+}
+
+// Suite groups the outcomes related to the same node, or to the cluster.
+type Suite struct {
+	Name     string
+	Outcomes []Outcome
+}
+
+// Collect matches checks with findings and groups them by node, preserving
+// the order on which checks were performed. Findings without a matching check
+// are reported as checks on their own.
+func Collect(checks []validator.Check, results []validator.ValidationResult) []Suite {
+	var suites []Suite
+	suiteIdx := make(map[string]int)
+	outcomeIdx := make(map[validator.Check]int)
+
+	addCheck := func(ck validator.Check) (int, int) {
+		name := suiteName(ck.Node)
+		sidx, ok := suiteIdx[name]
+		if !ok {
+			sidx = len(suites)
+			suites = append(suites, Suite{Name: name})
+			suiteIdx[name] = sidx
+		}
+		oidx, ok := outcomeIdx[ck]
+		if !ok {
+			oidx = len(suites[sidx].Outcomes)
+			suites[sidx].Outcomes = append(suites[sidx].Outcomes, Outcome{Check: ck})
+			outcomeIdx[ck] = oidx
+		}
+		return sidx, oidx
+	}
+
+	for _, ck := range checks {
+		addCheck(ck)
+	}
+	for _, res := range results {
+		sidx, oidx := addCheck(validator.Check{
+			Node:   res.Node,
+			Area:   res.Area,
+			RuleID: res.RuleID,
+		})
+		suites[sidx].Outcomes[oidx].Findings = append(suites[sidx].Outcomes[oidx].Findings, res)
+	}
+	return suites
+}
+
+func suiteName(node string) string {
+	if node == "" {
+		return SuiteCluster
+	}
+	return node
+}
+
+func checkName(ck validator.Check) string {
+	if ck.RuleID == "" {
+		return ck.Area
+	}
+	return ck.RuleID
+}
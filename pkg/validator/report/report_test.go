@@ -0,0 +1,139 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/validator"
+)
+
+var (
+	testChecks = []validator.Check{
+		{Area: validator.AreaCluster, RuleID: validator.RuleClusterAPIVersion},
+		{Node: "node-0", Area: validator.AreaKubelet, RuleID: validator.RuleCPUManagerPolicy},
+		{Node: "node-0", Area: validator.AreaKubelet, RuleID: validator.RuleTopologyManagerPolicy},
+		{Node: "node-1", Area: validator.AreaKubelet, RuleID: validator.RuleCPUManagerPolicy},
+		{Node: "node-1", Area: validator.AreaKubelet, RuleID: validator.RuleTopologyManagerPolicy},
+	}
+	testResults = []validator.ValidationResult{
+		{
+			Node:      "node-0",
+			Area:      validator.AreaKubelet,
+			Component: validator.ComponentTopologyManager,
+			Setting:   "policy",
+			Expected:  validator.ExpectedTopologyManagerPolicy,
+			Detected:  "none",
+			Severity:  validator.SeverityError,
+			RuleID:    validator.RuleTopologyManagerPolicy,
+		},
+		{
+			Node:      "node-1",
+			Area:      validator.AreaKubelet,
+			Component: validator.ComponentCPUManager,
+			Setting:   "policy",
+			Expected:  validator.ExpectedCPUManagerPolicy,
+			Detected:  "none",
+			Severity:  validator.SeverityWarning,
+			RuleID:    validator.RuleCPUManagerPolicy,
+		},
+	}
+)
+
+func TestCollect(t *testing.T) {
+	suites := Collect(testChecks, testResults)
+	if len(suites) != 3 {
+		t.Fatalf("expected 3 suites, got %d", len(suites))
+	}
+	expectedNames := []string{SuiteCluster, "node-0", "node-1"}
+	for idx, name := range expectedNames {
+		if suites[idx].Name != name {
+			t.Errorf("suite %d: expected name %q got %q", idx, name, suites[idx].Name)
+		}
+		if len(suites[idx].Outcomes) == 0 {
+			t.Errorf("suite %q: no outcomes", name)
+		}
+	}
+	if !suites[1].Outcomes[1].Failed() {
+		t.Errorf("expected failed outcome for %v", suites[1].Outcomes[1].Check)
+	}
+	if suites[2].Outcomes[0].Failed() {
+		t.Errorf("unexpected failed outcome for warning %v", suites[2].Outcomes[0].Check)
+	}
+}
+
+func TestCollectUnmatchedFinding(t *testing.T) {
+	suites := Collect(nil, testResults[:1])
+	if len(suites) != 1 || len(suites[0].Outcomes) != 1 {
+		t.Fatalf("unexpected suites: %#v", suites)
+	}
+	if !suites[0].Outcomes[0].Failed() {
+		t.Errorf("expected failed outcome")
+	}
+}
+
+func TestJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := JUnit(&buf, testChecks, testResults); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := junitTestSuites{}
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("cannot decode the generated report: %v\n%s", err, buf.String())
+	}
+	if got.Tests != len(testChecks) {
+		t.Errorf("expected %d tests, got %d", len(testChecks), got.Tests)
+	}
+	if got.Failures != 1 {
+		t.Errorf("expected 1 failure, got %d", got.Failures)
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SARIF(&buf, testChecks, testResults); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := sarifLog{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("cannot decode the generated report: %v\n%s", err, buf.String())
+	}
+	if len(got.Runs) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(got.Runs))
+	}
+
+	kinds := make(map[string]int)
+	levels := make(map[string]int)
+	for _, res := range got.Runs[0].Results {
+		kinds[res.Kind]++
+		levels[res.Level]++
+	}
+	if kinds["pass"] != 3 || kinds["fail"] != 2 {
+		t.Errorf("unexpected result kinds: %v", kinds)
+	}
+	if levels["error"] != 1 || levels["warning"] != 1 {
+		t.Errorf("unexpected result levels: %v", levels)
+	}
+	if len(got.Runs[0].Tool.Driver.Rules) != 3 {
+		t.Errorf("unexpected rules: %v", got.Runs[0].Tool.Driver.Rules)
+	}
+}
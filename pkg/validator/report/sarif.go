@@ -0,0 +1,159 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/k8stopologyawareschedwg/deployer/pkg/validator"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+
+	toolName = "deployer"
+	toolURI  = "https://github.com/k8stopologyawareschedwg/deployer"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID   string        `json:"id"`
+	Help *sarifMessage `json:"help,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	Kind      string          `json:"kind"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind"`
+}
+
+// SARIF writes the validation outcome as SARIF 2.1.0 log. Each finding is reported
+// as a result with the level matching its severity; checks without findings are
+// reported as results of kind "pass".
+func SARIF(w io.Writer, checks []validator.Check, results []validator.ValidationResult) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           toolName,
+				InformationURI: toolURI,
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	knownRules := make(map[string]bool)
+	for _, suite := range Collect(checks, results) {
+		for _, oc := range suite.Outcomes {
+			ruleID := oc.Check.RuleID
+			if ruleID != "" && !knownRules[ruleID] {
+				knownRules[ruleID] = true
+				rule := sarifRule{ID: ruleID}
+				if hint := validator.Remediation(ruleID); hint != "" {
+					rule.Help = &sarifMessage{Text: hint}
+				}
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+			}
+
+			loc := sarifLocation{
+				LogicalLocations: []sarifLogicalLocation{
+					{
+						Name:               suite.Name,
+						FullyQualifiedName: suite.Name + "/" + oc.Check.Area,
+						Kind:               "resource",
+					},
+				},
+			}
+
+			if len(oc.Findings) == 0 {
+				run.Results = append(run.Results, sarifResult{
+					RuleID:    ruleID,
+					Kind:      "pass",
+					Level:     "none",
+					Message:   sarifMessage{Text: "check passed"},
+					Locations: []sarifLocation{loc},
+				})
+				continue
+			}
+			for _, res := range oc.Findings {
+				run.Results = append(run.Results, sarifResult{
+					RuleID:    ruleID,
+					Kind:      "fail",
+					Level:     sarifLevel(res.GetSeverity()),
+					Message:   sarifMessage{Text: res.String()},
+					Locations: []sarifLocation{loc},
+				})
+			}
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+func sarifLevel(sev validator.Severity) string {
+	switch sev {
+	case validator.SeverityWarning:
+		return "warning"
+	case validator.SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+// rule IDs are part of the public output and must be kept stable
+const (
+	RuleClusterAPIVersion              = "cluster-api-version"
+	RuleClusterWorkerNodes             = "cluster-worker-nodes"
+	RuleKubeletConfiguration           = "kubelet-configuration"
+	RuleKubeletVersionSkew             = "kubelet-version-skew"
+	RuleKubeletPodResourcesAllocatable = "kubelet-podresources-allocatable"
+	RuleKubeletConsistency             = "kubelet-consistency"
+	RuleCPUManagerPolicy               = "kubelet-cpu-manager-policy"
+	RuleCPUManagerPolicyOptions        = "kubelet-cpu-manager-policy-options"
+	RuleCPUManagerReconcilePeriod      = "kubelet-cpu-manager-reconcile-period"
+	RuleReservedSystemCPUs             = "kubelet-reserved-system-cpus"
+	RuleMemoryManagerPolicy            = "kubelet-memory-manager-policy"
+	RuleReservedMemory                 = "kubelet-reserved-memory"
+	RuleReservedMemoryConsistency      = "kubelet-reserved-memory-consistency"
+	RuleTopologyManagerPolicy          = "kubelet-topology-manager-policy"
+	RuleTopologyManagerPolicyOptions   = "kubelet-topology-manager-policy-options"
+	RuleTopologyManagerScope           = "kubelet-topology-manager-scope"
+	RuleNRTPresent                     = "deployment-nrt-present"
+	RuleUpdaterAlive                   = "deployment-updater-alive"
+	RuleNRTZones                       = "deployment-nrt-zones"
+	RuleSchedulerConfig                = "deployment-scheduler-config"
+	RuleTestPod                        = "deployment-test-pod"
+	RuleOpenShiftMachineConfigPool     = "openshift-machine-config-pool"
+	RuleOpenShiftPoolRollout           = "openshift-pool-rollout"
+	RuleOpenShiftKubeletDrift          = "openshift-kubelet-config-drift"
+)
+
+var remediations = map[string]string{
+	RuleClusterAPIVersion:              "upgrade the cluster to kubernetes " + ExpectedMinKubeVersion + " or newer",
+	RuleClusterWorkerNodes:             "make sure the cluster has schedulable worker nodes and the kubelet configz endpoint is reachable through the API server proxy",
+	RuleKubeletConfiguration:           "make sure the kubelet configz endpoint is reachable through the API server proxy",
+	RuleKubeletVersionSkew:             "complete the upgrade of the control plane and of the nodes; kubelets must not be newer than the control plane",
+	RuleKubeletPodResourcesAllocatable: "upgrade the kubelet to a version supporting the podresources GetAllocatableResources API, needed by the topology updater",
+	RuleKubeletConsistency:             "align the kubelet configuration of the node with the other nodes, or label the node differently if the difference is intended",
+	RuleCPUManagerPolicy:               "set cpuManagerPolicy in the kubelet configuration and restart the kubelet after removing the CPU manager state file",
+	RuleCPUManagerPolicyOptions:        "set cpuManagerPolicyOptions in the kubelet configuration using only the options supported by the kubelet version",
+	RuleCPUManagerReconcilePeriod:      "set cpuManagerReconcilePeriod in the kubelet configuration within the recommended range",
+	RuleReservedSystemCPUs:             "set reservedSystemCPUs in the kubelet configuration to the CPUs reserved for the system and kubernetes daemons",
+	RuleMemoryManagerPolicy:            "set memoryManagerPolicy in the kubelet configuration and restart the kubelet after removing the memory manager state file",
+	RuleReservedMemory:                 "set reservedMemory in the kubelet configuration, one entry per NUMA node, consistent with kubeReserved, systemReserved and evictionHard",
+	RuleReservedMemoryConsistency:      "make the sum of reservedMemory across NUMA nodes equal to kubeReserved plus systemReserved plus the evictionHard memory threshold, for memory and each hugepages size",
+	RuleTopologyManagerPolicy:          "set topologyManagerPolicy in the kubelet configuration",
+	RuleTopologyManagerPolicyOptions:   "set topologyManagerPolicyOptions in the kubelet configuration using only the options supported by the kubelet version",
+	RuleTopologyManagerScope:           "set topologyManagerScope in the kubelet configuration",
+	RuleNRTPresent:                     "check the topology updater pods are running on the node and their logs",
+	RuleUpdaterAlive:                   "check the topology updater pod on the node, its logs and its events; a crashing updater leaves the NodeResourceTopology object stale",
+	RuleNRTZones:                       "check the topology updater logs on the node and the podresources API of the kubelet",
+	RuleSchedulerConfig:                "check the scheduler configmap content, or redeploy the scheduler plugin",
+	RuleTestPod:                        "check the topology-aware scheduler logs and the events of the test pod",
+	RuleOpenShiftMachineConfigPool:     "label the node so it is selected by a MachineConfigPool, its kubelet configuration cannot be managed otherwise",
+	RuleOpenShiftPoolRollout:           "wait for the MachineConfigPool to finish updating, then validate again; the live configuration may not reflect the desired one yet",
+	RuleOpenShiftKubeletDrift:          "check the MachineConfigPool status and the KubeletConfig conditions: the desired configuration was not applied to the node",
+}
+
+// Remediation returns a human readable hint to fix the issues reported by the given rule
+func Remediation(ruleID string) string {
+	return remediations[ruleID]
+}
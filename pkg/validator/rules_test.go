@@ -0,0 +1,92 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ */
+
+package validator
+
+import (
+	"testing"
+
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+func TestResultsCarryRuleAndRemediation(t *testing.T) {
+	vrs := ValidateClusterNodeKubeletConfig("testNode", nil, &kubeletconfigv1beta1.KubeletConfiguration{})
+	vrs = append(vrs, ValidateClusterVersion("v1.10.0")...)
+	if len(vrs) == 0 {
+		t.Fatalf("expected validation failures, got none")
+	}
+	for _, vr := range vrs {
+		if vr.RuleID == "" {
+			t.Errorf("missing rule ID for %s", vr.String())
+		}
+		if vr.Remediation == "" {
+			t.Errorf("missing remediation for rule %q", vr.RuleID)
+		}
+		if vr.Severity == "" {
+			t.Errorf("missing severity for rule %q", vr.RuleID)
+		}
+	}
+}
+
+func TestHasAtLeast(t *testing.T) {
+	type testCase struct {
+		name     string
+		results  []ValidationResult
+		severity Severity
+		expected bool
+	}
+
+	testCases := []testCase{
+		{
+			name:     "no results",
+			severity: SeverityInfo,
+			expected: false,
+		},
+		{
+			name:     "warning vs error threshold",
+			results:  []ValidationResult{{Severity: SeverityWarning}},
+			severity: SeverityError,
+			expected: false,
+		},
+		{
+			name:     "warning vs warning threshold",
+			results:  []ValidationResult{{Severity: SeverityInfo}, {Severity: SeverityWarning}},
+			severity: SeverityWarning,
+			expected: true,
+		},
+		{
+			name:     "error vs warning threshold",
+			results:  []ValidationResult{{Severity: SeverityError}},
+			severity: SeverityWarning,
+			expected: true,
+		},
+		{
+			name:     "unset severity means error",
+			results:  []ValidationResult{{}},
+			severity: SeverityError,
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := HasAtLeast(tc.results, tc.severity)
+			if got != tc.expected {
+				t.Errorf("got %v expected %v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/discovery"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 
 	"github.com/go-logr/logr"
 	"github.com/k8stopologyawareschedwg/deployer/pkg/clientutil"
@@ -33,9 +34,14 @@ const (
 
 type Validator struct {
 	Log logr.Logger
+	// Policy is the kubelet configuration policy to validate against. If nil, the strict policy is used.
+	Policy *Policy
 
-	results       []ValidationResult
-	serverVersion *version.Info
+	results []ValidationResult
+	checks  []Check
+	// kubelet configurations fetched by ValidateClusterConfig, by node name
+	kubeletConfigs map[string]*kubeletconfigv1beta1.KubeletConfiguration
+	serverVersion  *version.Info
 }
 
 func NewValidatorWithDiscoveryClient(logger logr.Logger, cli *discovery.DiscoveryClient) (*Validator, error) {
@@ -61,13 +67,59 @@ func (vd *Validator) Results() []ValidationResult {
 	return vd.results
 }
 
+// Checks returns all the checks performed so far, regardless of their outcome.
+func (vd *Validator) Checks() []Check {
+	return vd.checks
+}
+
+func (vd *Validator) GetPolicy() Policy {
+	if vd.Policy == nil {
+		return StrictPolicy()
+	}
+	return *vd.Policy
+}
+
+// Check records a rule evaluated against a node, or against the cluster if Node is empty.
+type Check struct {
+	Node   string `json:"node,omitempty"`
+	Area   string `json:"area"`
+	RuleID string `json:"ruleID"`
+}
+
 type ValidationResult struct {
-	Node      string `json:"node"`
-	Area      string `json:"area"`
-	Component string `json:"component"`
-	Setting   string `json:"setting"`
-	Expected  string `json:"expected"`
-	Detected  string `json:"detected"`
+	Node      string   `json:"node"`
+	Area      string   `json:"area"`
+	Component string   `json:"component"`
+	Setting   string   `json:"setting"`
+	Expected  string   `json:"expected"`
+	Detected  string   `json:"detected"`
+	Severity  Severity `json:"severity,omitempty"`
+	// RuleID is a stable identifier of the check which produced this result
+	RuleID string `json:"ruleID,omitempty"`
+	// Remediation is a human readable hint to fix the reported issue
+	Remediation string `json:"remediation,omitempty"`
+	// Target is the object to change to fix the issue, in the "Kind/name" form, if not the node itself
+	Target string `json:"target,omitempty"`
+}
+
+// GetSeverity returns the result severity, defaulting to error if unset.
+func (vr ValidationResult) GetSeverity() Severity {
+	return severityOrDefault(vr.Severity)
+}
+
+// IsAtLeast returns true if the result severity is at least as high as the given one.
+func (vr ValidationResult) IsAtLeast(sev Severity) bool {
+	return severityRank(vr.GetSeverity()) >= severityRank(sev)
+}
+
+// HasAtLeast returns true if any of the results has a severity at least as high as the given one.
+func HasAtLeast(vrs []ValidationResult, sev Severity) bool {
+	for _, vr := range vrs {
+		if vr.IsAtLeast(sev) {
+			return true
+		}
+	}
+	return false
 }
 
 func (vr ValidationResult) String() string {